@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package apidiff computes the API changes between two FileDescriptorSets,
+// such as added, removed, and changed services, methods, messages, fields,
+// enums, and enum values.
+//
+// Unlike the compatible package, which checks for breaking changes, this
+// reports every change, and is meant to generate changelogs.
+package apidiff
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// ChangeKind is the kind of a Change.
+type ChangeKind string
+
+// The different kinds of changes.
+const (
+	Added   ChangeKind = "added"
+	Removed ChangeKind = "removed"
+	Changed ChangeKind = "changed"
+)
+
+// Change represents an API change.
+//
+// Added and changed elements are located in the updated files, and
+// removed elements are located in the original files.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// The type of the element, for example "Message" or "Field".
+	Type string `json:"type"`
+	// The fully-qualified name of the element, as in foo.v1.Bar.
+	// Fields and enum values are followed by their number, as in
+	// foo.v1.Bar.baz (1).
+	Name string `json:"name"`
+	// The full path to the filename, as in foo/bar.proto.
+	Filename string `json:"filename"`
+	Line     int32  `json:"line"`
+	Column   int32  `json:"column"`
+	// Only set for changed elements. The attribute is "comments"
+	// if the leading or trailing comments of the element changed.
+	Attribute string `json:"attribute,omitempty"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+}
+
+// String returns a string representation of the Change type.
+// The string is of the following form,
+//
+//  $(FILENAME):$(LINE):$(COLUMN):$(MESSAGE)
+//
+//  For example,
+//   "foo.proto:5:10:Field foo.Bar.baz (1) had its type changed from "int32" to "int64"."
+func (c Change) String() string {
+	return fmt.Sprintf("%s:%d:%d:%s", c.Filename, c.Line, c.Column, c.Message())
+}
+
+// Message returns a human-readable description of the Change.
+func (c Change) Message() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("%s %s was added.", c.Type, c.Name)
+	case Removed:
+		return fmt.Sprintf("%s %s was removed.", c.Type, c.Name)
+	default:
+		if c.Attribute == _commentsAttribute {
+			return fmt.Sprintf("%s %s had its comments changed.", c.Type, c.Name)
+		}
+		return fmt.Sprintf("%s %s had its %s changed from %q to %q.", c.Type, c.Name, c.Attribute, c.From, c.To)
+	}
+}
+
+// Diff returns the changes from "from" to "to".
+//
+// Elements are compared by their fully-qualified name, except for fields
+// and enum values, which are compared by their number within their parent,
+// so that renames are reported as changes. Moving an element to another
+// file is not a change.
+//
+// The changes are sorted by kind, name, and attribute.
+func Diff(from, to *descriptor.FileDescriptorSet) []Change {
+	original, updated := newElements(from), newElements(to)
+	var changes []Change
+	for key, o := range original {
+		if _, ok := updated[key]; !ok {
+			changes = append(changes, o.newChange(Removed, "", "", ""))
+		}
+	}
+	for key, u := range updated {
+		o, ok := original[key]
+		if !ok {
+			changes = append(changes, u.newChange(Added, "", "", ""))
+			continue
+		}
+		for _, attribute := range o.attributes {
+			if updatedValue := u.attribute(attribute.name); attribute.value != updatedValue {
+				changes = append(changes, u.newChange(Changed, attribute.name, attribute.value, updatedValue))
+			}
+		}
+		if o.comments != u.comments {
+			changes = append(changes, u.newChange(Changed, _commentsAttribute, o.comments, u.comments))
+		}
+	}
+	sort.Slice(changes, func(i int, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return _kindToOrder[changes[i].Kind] < _kindToOrder[changes[j].Kind]
+		}
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Attribute < changes[j].Attribute
+	})
+	return changes
+}
+
+var _kindToOrder = map[ChangeKind]int{
+	Added:   0,
+	Removed: 1,
+	Changed: 2,
+}
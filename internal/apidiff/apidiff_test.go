@@ -0,0 +1,147 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package apidiff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/prototool/internal/location"
+)
+
+func TestDiff(t *testing.T) {
+	int32Type := descriptor.FieldDescriptorProto_TYPE_INT32
+	int64Type := descriptor.FieldDescriptorProto_TYPE_INT64
+	original := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{
+			{
+				Name:    proto.String("foo.proto"),
+				Package: proto.String("foo"),
+				MessageType: []*descriptor.DescriptorProto{
+					{
+						Name: proto.String("Foo"),
+						Field: []*descriptor.FieldDescriptorProto{
+							{Name: proto.String("one"), Number: proto.Int32(1), Type: &int32Type},
+							{Name: proto.String("two"), Number: proto.Int32(2), Type: &int32Type},
+						},
+					},
+				},
+				EnumType: []*descriptor.EnumDescriptorProto{
+					{
+						Name: proto.String("Bar"),
+						Value: []*descriptor.EnumValueDescriptorProto{
+							{Name: proto.String("BAR_INVALID"), Number: proto.Int32(0)},
+						},
+					},
+				},
+				SourceCodeInfo: &descriptor.SourceCodeInfo{
+					Location: []*descriptor.SourceCodeInfo_Location{
+						{
+							Path:            location.Path{}.Scope(location.Message, 0),
+							Span:            []int32{2, 0, 5, 1},
+							LeadingComments: proto.String(" Foo is a foo.\n"),
+						},
+					},
+				},
+			},
+		},
+	}
+	updated := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{
+			{
+				Name:    proto.String("foo.proto"),
+				Package: proto.String("foo"),
+				MessageType: []*descriptor.DescriptorProto{
+					{
+						Name: proto.String("Foo"),
+						Field: []*descriptor.FieldDescriptorProto{
+							{Name: proto.String("one"), Number: proto.Int32(1), Type: &int64Type},
+							{Name: proto.String("three"), Number: proto.Int32(3), Type: &int32Type},
+						},
+					},
+				},
+				SourceCodeInfo: &descriptor.SourceCodeInfo{
+					Location: []*descriptor.SourceCodeInfo_Location{
+						{
+							Path:            location.Path{}.Scope(location.Message, 0),
+							Span:            []int32{4, 0, 7, 1},
+							LeadingComments: proto.String(" Foo is a foo with more fields.\n"),
+						},
+					},
+				},
+			},
+			{
+				// Bar moved to another file
+				Name:    proto.String("bar.proto"),
+				Package: proto.String("foo"),
+				EnumType: []*descriptor.EnumDescriptorProto{
+					{
+						Name: proto.String("Bar"),
+						Value: []*descriptor.EnumValueDescriptorProto{
+							{Name: proto.String("BAR_UNSPECIFIED"), Number: proto.Int32(0)},
+						},
+					},
+				},
+			},
+		},
+	}
+	changes := Diff(original, updated)
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.String())
+	}
+	assert.Equal(
+		t,
+		[]string{
+			`foo.proto:1:1:Field foo.Foo.three (3) was added.`,
+			`foo.proto:1:1:Field foo.Foo.two (2) was removed.`,
+			`bar.proto:1:1:Enum value foo.Bar.BAR_UNSPECIFIED (0) had its name changed from "BAR_INVALID" to "BAR_UNSPECIFIED".`,
+			`foo.proto:5:1:Message foo.Foo had its comments changed.`,
+			`foo.proto:1:1:Field foo.Foo.one (1) had its type changed from "int32" to "int64".`,
+		},
+		got,
+	)
+
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, PrintMarkdown(buffer, changes))
+	assert.Equal(
+		t,
+		"## Added\n\n"+
+			"- Field `foo.Foo.three (3)` (`foo.proto:1:1`)\n"+
+			"\n## Removed\n\n"+
+			"- Field `foo.Foo.two (2)` (`foo.proto:1:1`)\n"+
+			"\n## Changed\n\n"+
+			"- Enum value `foo.Bar.BAR_UNSPECIFIED (0)`: name changed from `BAR_INVALID` to `BAR_UNSPECIFIED` (`bar.proto:1:1`)\n"+
+			"- Message `foo.Foo`: comments changed (`foo.proto:5:1`)\n"+
+			"- Field `foo.Foo.one (1)`: type changed from `int32` to `int64` (`foo.proto:1:1`)\n",
+		buffer.String(),
+	)
+}
+
+func TestPrintMarkdownNoChanges(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, PrintMarkdown(buffer, nil))
+	assert.Equal(t, "No changes.\n", buffer.String())
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package apidiff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/location"
+)
+
+const _commentsAttribute = "comments"
+
+// element represents a service, method, message, field,
+// enum, or enum value.
+type element struct {
+	typ        string
+	name       string
+	filename   string
+	span       location.Span
+	comments   string
+	attributes []attribute
+}
+
+// attribute is a named attribute of an element, such as the
+// type of a field. The attributes of an element of a given
+// type are always in the same order.
+type attribute struct {
+	name  string
+	value string
+}
+
+// attribute returns the value of the attribute with the given name.
+func (e *element) attribute(name string) string {
+	for _, attribute := range e.attributes {
+		if attribute.name == name {
+			return attribute.value
+		}
+	}
+	return ""
+}
+
+func (e *element) newChange(kind ChangeKind, attribute string, from string, to string) Change {
+	return Change{
+		Kind:      kind,
+		Type:      e.typ,
+		Name:      e.name,
+		Filename:  e.filename,
+		Line:      e.span.Line(),
+		Column:    e.span.Col(),
+		Attribute: attribute,
+		From:      from,
+		To:        to,
+	}
+}
+
+// elements are keyed by the type of the element, and its fully-qualified
+// name or, for fields and enum values, the fully-qualified name of its
+// parent and its number.
+type elements map[string]*element
+
+func newElements(fileDescriptorSet *descriptor.FileDescriptorSet) elements {
+	var basePath location.Path
+	elements := make(elements)
+	for _, fd := range fileDescriptorSet.GetFile() {
+		b := &elementBuilder{
+			elements: elements,
+			filename: fd.GetName(),
+			finder:   location.NewFinder(fd.GetSourceCodeInfo()),
+		}
+		for i, md := range fd.GetMessageType() {
+			b.addMessage(md, fd.GetPackage(), basePath.Scope(location.Message, i))
+		}
+		for i, ed := range fd.GetEnumType() {
+			b.addEnum(ed, fd.GetPackage(), basePath.Scope(location.Enum, i))
+		}
+		for i, sd := range fd.GetService() {
+			b.addService(sd, fd.GetPackage(), basePath.Scope(location.Service, i))
+		}
+	}
+	return elements
+}
+
+// elementBuilder adds the elements of a single file.
+type elementBuilder struct {
+	elements elements
+	filename string
+	finder   *location.Finder
+}
+
+func (b *elementBuilder) add(key string, typ string, name string, p location.Path, attributes ...attribute) {
+	loc, _ := b.finder.Find(p)
+	b.elements[typ+" "+key] = &element{
+		typ:        typ,
+		name:       name,
+		filename:   b.filename,
+		span:       loc.Span,
+		comments:   strings.TrimSpace(strings.TrimSpace(loc.Comments.Leading) + "\n" + strings.TrimSpace(loc.Comments.Trailing)),
+		attributes: attributes,
+	}
+}
+
+func (b *elementBuilder) addMessage(md *descriptor.DescriptorProto, prefix string, p location.Path) {
+	name := qualify(prefix, md.GetName())
+	b.add(name, "Message", name, p)
+	for i, fd := range md.GetField() {
+		oneof := ""
+		if fd.OneofIndex != nil && int(fd.GetOneofIndex()) < len(md.GetOneofDecl()) {
+			oneof = md.GetOneofDecl()[fd.GetOneofIndex()].GetName()
+		}
+		b.add(
+			fmt.Sprintf("%s.%d", name, fd.GetNumber()),
+			"Field",
+			fmt.Sprintf("%s (%d)", qualify(name, fd.GetName()), fd.GetNumber()),
+			p.Scope(location.Field, i),
+			attribute{name: "name", value: fd.GetName()},
+			attribute{name: "json name", value: fd.GetJsonName()},
+			attribute{name: "label", value: strings.ToLower(strings.TrimPrefix(fd.GetLabel().String(), "LABEL_"))},
+			attribute{name: "type", value: getFieldType(fd)},
+			attribute{name: "oneof", value: oneof},
+			attribute{name: "default value", value: fd.GetDefaultValue()},
+		)
+	}
+	for i, nested := range md.GetNestedType() {
+		b.addMessage(nested, name, p.Scope(location.NestedType, i))
+	}
+	for i, ed := range md.GetEnumType() {
+		b.addEnum(ed, name, p.Scope(location.MessageEnum, i))
+	}
+}
+
+func (b *elementBuilder) addEnum(ed *descriptor.EnumDescriptorProto, prefix string, p location.Path) {
+	name := qualify(prefix, ed.GetName())
+	b.add(name, "Enum", name, p)
+	for i, vd := range ed.GetValue() {
+		b.add(
+			fmt.Sprintf("%s.%d", name, vd.GetNumber()),
+			"Enum value",
+			fmt.Sprintf("%s (%d)", qualify(name, vd.GetName()), vd.GetNumber()),
+			p.Scope(location.EnumValue, i),
+			attribute{name: "name", value: vd.GetName()},
+		)
+	}
+}
+
+func (b *elementBuilder) addService(sd *descriptor.ServiceDescriptorProto, prefix string, p location.Path) {
+	name := qualify(prefix, sd.GetName())
+	b.add(name, "Service", name, p)
+	for i, md := range sd.GetMethod() {
+		methodName := qualify(name, md.GetName())
+		b.add(
+			methodName,
+			"Method",
+			methodName,
+			p.Scope(location.Method, i),
+			attribute{name: "request type", value: strings.TrimPrefix(md.GetInputType(), ".")},
+			attribute{name: "response type", value: strings.TrimPrefix(md.GetOutputType(), ".")},
+			attribute{name: "client streaming", value: strconv.FormatBool(md.GetClientStreaming())},
+			attribute{name: "server streaming", value: strconv.FormatBool(md.GetServerStreaming())},
+		)
+	}
+}
+
+// getFieldType returns the type name of the field if set, or
+// the lowercase type otherwise, as in "foo.Bar" or "int32".
+func getFieldType(fd *descriptor.FieldDescriptorProto) string {
+	if typeName := strings.TrimPrefix(fd.GetTypeName(), "."); typeName != "" {
+		return typeName
+	}
+	return strings.ToLower(strings.TrimPrefix(fd.GetType().String(), "TYPE_"))
+}
+
+func qualify(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package apidiff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+var _kindToTitle = map[ChangeKind]string{
+	Added:   "Added",
+	Removed: "Removed",
+	Changed: "Changed",
+}
+
+// PrintMarkdown prints the changes as a Markdown changelog, with a
+// section for each kind of change. The changes are expected to be
+// sorted as returned by Diff.
+func PrintMarkdown(writer io.Writer, changes []Change) error {
+	bufWriter := bufio.NewWriter(writer)
+	if len(changes) == 0 {
+		if _, err := fmt.Fprintln(bufWriter, "No changes."); err != nil {
+			return err
+		}
+		return bufWriter.Flush()
+	}
+	var kind ChangeKind
+	for i, change := range changes {
+		if i == 0 || change.Kind != kind {
+			kind = change.Kind
+			if i != 0 {
+				if _, err := fmt.Fprintln(bufWriter); err != nil {
+					return err
+				}
+			}
+			if _, err := fmt.Fprintf(bufWriter, "## %s\n\n", _kindToTitle[kind]); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(bufWriter, "- %s `%s`", change.Type, change.Name); err != nil {
+			return err
+		}
+		if change.Kind == Changed {
+			if change.Attribute == _commentsAttribute {
+				if _, err := fmt.Fprint(bufWriter, ": comments changed"); err != nil {
+					return err
+				}
+			} else if _, err := fmt.Fprintf(bufWriter, ": %s changed from %s to %s", change.Attribute, markdownValue(change.From), markdownValue(change.To)); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(bufWriter, " (`%s:%d:%d`)\n", change.Filename, change.Line, change.Column); err != nil {
+			return err
+		}
+	}
+	return bufWriter.Flush()
+}
+
+// markdownValue formats the value of an attribute as code, or
+// returns "none" if the value is empty.
+func markdownValue(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return "`" + value + "`"
+}
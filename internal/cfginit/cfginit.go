@@ -67,11 +67,17 @@ protoc:
 # Lint directives.
 {{.V}}lint:
   # Linter files to ignore.
+  # Files can be paths, directories, or glob patterns where ** matches any number of directories.
+  # Packages can be package names or glob patterns such as foo.v1alpha.*.
 {{.V}}  ignores:
 {{.V}}    - id: RPC_NAMES_CAMEL_CASE
 {{.V}}      files:
 {{.V}}        - path/to/foo.proto
 {{.V}}        - path/to/bar.proto
+{{.V}}        - path/to/vendor
+{{.V}}        - "**/*_internal.proto"
+{{.V}}      packages:
+{{.V}}        - foo.v1alpha.*
 {{.V}}    - id: SYNTAX_PROTO3
 {{.V}}      files:
 {{.V}}        - path/to/foo.proto
@@ -82,6 +88,11 @@ protoc:
     # Determines whether or not to include the default set of linters.
 {{.V}}    no_default: true
 
+    # The group of linters to use instead of the default set of linters.
+    # This is either a built-in group or one of the groups defined below.
+    # Run prototool list-all-lint-groups to see all available groups.
+{{.V}}    group: public-api
+
     # The specific linters to add.
 {{.V}}    add:
 {{.V}}      - ENUM_NAMES_CAMEL_CASE
@@ -91,6 +102,82 @@ protoc:
 {{.V}}    remove:
 {{.V}}      - ENUM_NAMES_CAMEL_CASE
 
+    # The parameters of the linters that take parameters.
+    # Run prototool lint --list-all-linters to see the parameters of each linter.
+{{.V}}    parameters:
+{{.V}}      ENUM_ZERO_VALUES_INVALID:
+{{.V}}        suffix: UNSPECIFIED
+{{.V}}      MESSAGE_FIELD_NAMES:
+{{.V}}        style: lower_snake_case
+
+    # The severities of the linters, one of error, warning, info.
+    # The default severity is error. Only errors result in a non-zero exit
+    # code, or errors and warnings if prototool lint --strict is run.
+    # The severity of warnings and infos is printed before the message,
+    # as in foo.proto:10:1:warning:Message "Foo" needs a comment.
+{{.V}}    severities:
+{{.V}}      MESSAGES_HAVE_COMMENTS: warning
+
+  # Custom groups of linters.
+{{.V}}  groups:
+{{.V}}    - name: public-api
+      # The built-in group to start from, either default, all or aip.
+      # If not set, the group starts with no linters.
+{{.V}}      group: default
+      # The linters to add to the group.
+{{.V}}      add:
+{{.V}}        - MESSAGES_HAVE_COMMENTS
+      # The linters to remove from the group.
+{{.V}}      remove:
+{{.V}}        - ENUM_NAMES_CAMEL_CASE
+
+  # Configuration files to load the lint groups of, relative to this file.
+  # This can be used to share groups between repositories.
+{{.V}}  group_files:
+{{.V}}    - path/to/shared/prototool.yaml
+
+  # The path to the baseline file of lint failures to ignore, relative to this file.
+  # Failures are matched by file, linter, and element name, so only new failures are reported.
+  # Run prototool lint --generate-baseline to write the current lint failures to this file.
+{{.V}}  baseline: lint_baseline.json
+
+  # External lint plugins.
+  # Each plugin is sent the compiled FileDescriptorSet as JSON on stdin,
+  # and returns its lint failures as JSON on stdout.
+{{.V}}  plugins:
+    # The path to the plugin. Paths with a separator are relative to
+    # this file, otherwise the plugin is looked up on your PATH.
+{{.V}}    - path: ./bin/prototool-lint-acme
+      # The prefix of the IDs of the failures of the plugin.
+      # For example, a failure with the ID FOO is reported as ACME_FOO.
+{{.V}}      id_prefix: ACME
+      # Parameters to pass to the plugin.
+{{.V}}      parameters:
+{{.V}}        max_fields: 50
+
+# Breaking change detection directives.
+{{.V}}break:
+  # The minimum severity of breaking changes that result in a failure.
+  # Valid severities are wire, source, warn.
+  # By default, wire and source breaking changes result in a failure.
+{{.V}}  fail_severity: wire
+
+  # Packages and files to ignore breaking changes for.
+{{.V}}  ignores:
+{{.V}}    packages:
+{{.V}}      - foo.v1
+{{.V}}    files:
+{{.V}}      - path/to/foo.proto
+
+  # Ignore breaking changes for packages with an alpha or beta version
+  # suffix, such as foo.v1alpha1 or foo.v1beta1.
+{{.V}}  ignore_unstable_packages: true
+
+  # Compare types by their fully-qualified name within their package
+  # instead of comparing files by name. This allows types to be moved
+  # between files of the same package without reporting a breaking change.
+{{.V}}  package_scope: true
+
 # Code generation directives.
 {{.V}}generate:
   # Options that will apply to all plugins of type go and gogo.
@@ -101,8 +101,14 @@ func getRootCommand(exitCodeAddr *int, develMode bool, args []string, stdin io.R
 
 	rootCmd := &cobra.Command{Use: "prototool"}
 	rootCmd.AddCommand(allCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
+	breakCmd := &cobra.Command{Use: "break"}
+	breakCmd.AddCommand(breakCheckCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
+	breakCmd.AddCommand(breakDescriptorSetCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
+	breakCmd.AddCommand(breakSuggestVersionCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
+	rootCmd.AddCommand(breakCmd)
 	rootCmd.AddCommand(compileCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
 	rootCmd.AddCommand(createCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
+	rootCmd.AddCommand(diffAPICmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
 	rootCmd.AddCommand(filesCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
 	rootCmd.AddCommand(formatCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
 	rootCmd.AddCommand(generateCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
@@ -110,7 +116,9 @@ func getRootCommand(exitCodeAddr *int, develMode bool, args []string, stdin io.R
 	configCmd := &cobra.Command{Use: "config"}
 	configCmd.AddCommand(configInitCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
 	rootCmd.AddCommand(configCmd)
-	rootCmd.AddCommand(lintCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
+	lintCmd := lintCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags)
+	lintCmd.AddCommand(lintExplainCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
+	rootCmd.AddCommand(lintCmd)
 	rootCmd.AddCommand(versionCmdTemplate.Build(exitCodeAddr, stdin, stdout, stderr, flags))
 
 	// flags bound to rootCmd are global flags
@@ -379,6 +379,342 @@ func TestLint(t *testing.T) {
 		51:7:ENUM_ZERO_VALUES_INVALID_EXCEPT_MESSAGE`,
 		"testdata/lint/enumexceptmessages/foo.proto",
 	)
+	assertDoLintFile(
+		t,
+		false,
+		`20:3:ENUM_FIELD_PREFIXES
+		23:1:LINT_IGNORE_COMMENTS_USED`,
+		"testdata/lint/ignorecomments/foo.proto",
+	)
+}
+
+func TestLintBaseline(t *testing.T) {
+	t.Parallel()
+	assertDoLintFile(
+		t,
+		false,
+		`14:1:MESSAGES_HAVE_COMMENTS
+		18:3:MESSAGES_HAVE_COMMENTS`,
+		"testdata/lint/baseline/foo.proto",
+	)
+
+	tempDirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tempDirPath) }()
+	for _, filename := range []string{settings.DefaultConfigFilename, "foo.proto"} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata/lint/baseline", filename))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(tempDirPath, filename), data, 0644))
+	}
+	assertExact(t, 0, "", "lint", tempDirPath, "--generate-baseline")
+	assertExact(t, 0, "", "lint", tempDirPath)
+	data, err := ioutil.ReadFile(filepath.Join(tempDirPath, "lint_baseline.json"))
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{
+  "failures": [
+    {
+      "filename": "foo.proto",
+      "lint_id": "MESSAGES_HAVE_COMMENTS",
+      "element": "Bar"
+    },
+    {
+      "filename": "foo.proto",
+      "lint_id": "MESSAGES_HAVE_COMMENTS",
+      "element": "Foo"
+    },
+    {
+      "filename": "foo.proto",
+      "lint_id": "MESSAGES_HAVE_COMMENTS",
+      "element": "Foo.Baz"
+    },
+    {
+      "filename": "foo.proto",
+      "lint_id": "MESSAGES_HAVE_COMMENTS",
+      "element": "Qux.Baz"
+    }
+  ]
+}
+`,
+		string(data),
+	)
+}
+
+func TestLintFix(t *testing.T) {
+	t.Parallel()
+	tempDirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tempDirPath) }()
+	for _, filename := range []string{settings.DefaultConfigFilename, "foo.proto", "bar.proto"} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata/lint/fix", filename))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(tempDirPath, filename), data, 0644))
+	}
+	assertExact(t, 0, "", "lint", tempDirPath, "--fix")
+	for _, filename := range []string{"foo.proto", "bar.proto"} {
+		golden, err := ioutil.ReadFile(filepath.Join("testdata/lint/fix", filename+".golden"))
+		require.NoError(t, err)
+		data, err := ioutil.ReadFile(filepath.Join(tempDirPath, filename))
+		require.NoError(t, err)
+		assert.Equal(t, string(golden), string(data))
+	}
+}
+
+func TestLintFixEnumValues(t *testing.T) {
+	t.Parallel()
+	tempDirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tempDirPath) }()
+	for _, filename := range []string{settings.DefaultConfigFilename, "foo.proto"} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata/lint/fixenumvalues", filename))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(tempDirPath, filename), data, 0644))
+	}
+	// enum values that are used as option values are not renamed
+	output, exitCode := testDo(t, "lint", tempDirPath, "--fix")
+	assert.Equal(t, 255, exitCode)
+	lines := getCleanLines(output)
+	require.Len(t, lines, 1)
+	assert.True(t, strings.HasSuffix(lines[0], `foo.proto:7:3:ENUM_FIELD_PREFIXES:Enum field "WORLD" is expected to have the prefix "HELLO_".`), lines[0])
+	golden, err := ioutil.ReadFile("testdata/lint/fixenumvalues/foo.proto.golden")
+	require.NoError(t, err)
+	data, err := ioutil.ReadFile(filepath.Join(tempDirPath, "foo.proto"))
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(data))
+}
+
+func TestLintDescriptor(t *testing.T) {
+	t.Parallel()
+	assertDoLintFile(
+		t,
+		false,
+		`17:3:REQUEST_RESPONSE_TYPES_IN_SAME_FILE
+		18:3:REQUEST_RESPONSE_TYPES_IN_SAME_FILE
+		18:3:REQUEST_RESPONSE_TYPES_IN_SAME_FILE`,
+		"testdata/lint/descriptor/foo.proto",
+	)
+}
+
+func TestLintPlugin(t *testing.T) {
+	t.Parallel()
+	assertDoLintFile(
+		t,
+		false,
+		`15:1:ACME_FORBIDDEN_MESSAGE`,
+		"testdata/lint/plugin/foo.proto",
+	)
+}
+
+func TestLintParameters(t *testing.T) {
+	t.Parallel()
+	assertDoLintFile(
+		t,
+		false,
+		`13:3:MESSAGE_FIELDS_NOT_FLOATS
+		13:3:MESSAGE_FIELD_NAMES
+		17:1:MESSAGES_HAVE_COMMENTS
+		24:3:ENUM_ZERO_VALUES_INVALID_EXCEPT_MESSAGE`,
+		"testdata/lint/parameters/foo.proto",
+	)
+}
+
+func TestLintSeverity(t *testing.T) {
+	t.Parallel()
+	assertDoLintFile(
+		t,
+		true,
+		`10:1:MESSAGES_HAVE_COMMENTS
+		12:1:ENUMS_HAVE_COMMENTS`,
+		"testdata/lint/severity/foo.proto",
+	)
+	assertDoLintFile(
+		t,
+		false,
+		`10:1:MESSAGES_HAVE_COMMENTS
+		12:1:ENUMS_HAVE_COMMENTS`,
+		"testdata/lint/severity/foo.proto",
+		"--strict",
+	)
+	// the severity of failures that are not errors is printed with the default print fields
+	testDownload(t)
+	buffer := bytes.NewBuffer(nil)
+	exitCode := do(true, []string{"lint", "testdata/lint/severity/foo.proto"}, os.Stdin, buffer, buffer)
+	assert.Equal(t, 0, exitCode)
+	lines := getCleanLines(buffer.String())
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "testdata/lint/severity/foo.proto:10:1:warning:"), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "testdata/lint/severity/foo.proto:12:1:info:"), lines[1])
+}
+
+func TestLintIgnoreGlobs(t *testing.T) {
+	t.Parallel()
+	assertDoLintFiles(
+		t,
+		false,
+		`testdata/lint/ignoreglobs/foo.proto:5:1:MESSAGES_HAVE_COMMENTS`,
+		"testdata/lint/ignoreglobs",
+	)
+	assertExact(
+		t,
+		1,
+		`invalid lint ignore file "foo/[" for MESSAGES_HAVE_COMMENTS: syntax error in pattern`,
+		"lint",
+		"testdata/lint/ignoreglobs",
+		"--config-data",
+		`{"lint":{"ignores":[{"id":"MESSAGES_HAVE_COMMENTS","files":["foo/["]}]}}`,
+	)
+	assertExact(
+		t,
+		1,
+		`invalid lint ignore package "foo.[" for MESSAGES_HAVE_COMMENTS: syntax error in pattern`,
+		"lint",
+		"testdata/lint/ignoreglobs",
+		"--config-data",
+		`{"lint":{"ignores":[{"id":"MESSAGES_HAVE_COMMENTS","packages":["foo.["]}]}}`,
+	)
+}
+
+func TestLintGroups(t *testing.T) {
+	t.Parallel()
+	assertDoLintFile(
+		t,
+		false,
+		`5:1:MESSAGES_HAVE_COMMENTS
+		7:1:ENUMS_HAVE_COMMENTS`,
+		"testdata/lint/groups/foo.proto",
+	)
+}
+
+func TestLintAIP(t *testing.T) {
+	t.Parallel()
+	assertDoLintFile(
+		t,
+		false,
+		`7:1:AIP_RESOURCE_NAME_FIELDS
+		36:3:AIP_LIST_PAGINATION
+		37:3:AIP_UPDATE_MASK_FIELD_MASK`,
+		"testdata/lint/aip/foo.proto",
+	)
+}
+
+func TestLintUnused(t *testing.T) {
+	t.Parallel()
+	assertDoLintFiles(
+		t,
+		false,
+		`testdata/lint/unused/bar.proto:6:3:UNUSED_TYPES
+		testdata/lint/unused/bar.proto:10:1:UNUSED_TYPES
+		testdata/lint/unused/foo.proto:6:1:UNUSED_IMPORTS
+		testdata/lint/unused/foo.proto:18:1:UNUSED_TYPES`,
+		"testdata/lint/unused",
+	)
+}
+
+func TestLintImportRules(t *testing.T) {
+	t.Parallel()
+	assertDoLintFiles(
+		t,
+		false,
+		`testdata/lint/importrules/foo/billing/v1/invoice.proto:5:1:PACKAGES_NO_IMPORT_CYCLES
+		testdata/lint/importrules/foo/common/v1/common.proto:5:1:PACKAGE_IMPORT_RULES
+		testdata/lint/importrules/foo/service/v1/service.proto:5:1:PACKAGES_NO_IMPORT_CYCLES`,
+		"testdata/lint/importrules",
+	)
+	assertExact(
+		t,
+		1,
+		`invalid value for parameter "forbidden" for linter PACKAGE_IMPORT_RULES: invalid rule "foo.common.**", rules must be of the form "foo.common.** -> foo.service.**"`,
+		"lint",
+		"--list-linters",
+		"--config-data",
+		`{"lint":{"rules":{"add":["PACKAGE_IMPORT_RULES"],"parameters":{"PACKAGE_IMPORT_RULES":{"forbidden":"foo.common.**"}}}}}`,
+	)
+	assertExact(
+		t,
+		1,
+		`invalid value for parameter "forbidden" for linter PACKAGE_IMPORT_RULES: invalid pattern "foo.[" in rule "foo.[ -> foo.service.**": syntax error in pattern`,
+		"lint",
+		"--list-linters",
+		"--config-data",
+		`{"lint":{"rules":{"add":["PACKAGE_IMPORT_RULES"],"parameters":{"PACKAGE_IMPORT_RULES":{"forbidden":"foo.[ -> foo.service.**"}}}}}`,
+	)
+}
+
+func TestLintFileNames(t *testing.T) {
+	t.Parallel()
+	assertDoLintFiles(
+		t,
+		false,
+		`testdata/lint/filenames/foo/v1/FooBaz.proto:1:1:FILE_NAMES_LOWER_SNAKE_CASE
+		testdata/lint/filenames/foo/v1/import.proto:1:1:FILE_NAMES_NOT_RESERVED_WORDS
+		testdata/lint/filenames/foo/v2/foo.proto:3:1:FILE_PATH_MATCHES_PACKAGE`,
+		"testdata/lint/filenames",
+	)
+}
+
+func TestLintPrevious(t *testing.T) {
+	t.Parallel()
+	assertDoLintFile(
+		t,
+		true,
+		"",
+		"testdata/foo/success.proto",
+		"--git-branch",
+		"HEAD",
+	)
+	assertDo(t, 255, "must set only one of git-branch or descriptor-set-path", "lint", "testdata/foo/success.proto", "--git-branch", "HEAD", "--descriptor-set-path", "descriptor_set.bin")
+}
+
+func TestLintExplain(t *testing.T) {
+	t.Parallel()
+	assertExact(
+		t,
+		0,
+		`PACKAGE_MAJOR_VERSIONED
+
+Verifies that the package is of the form "package.vMAJORVERSION".
+
+In the default group: no
+
+Versioning packages lets a breaking change be made in a new major version, such as foo.v2, while clients keep using the old one. Add a major version as the last component of the package.
+
+Failing example:
+
+  // foo.proto
+  syntax = "proto3";
+
+  package foo;
+
+Passing example:
+
+  // foo.proto
+  syntax = "proto3";
+
+  package foo.v1;`,
+		"lint", "explain", "package_major_versioned",
+	)
+	assertDo(t, 255, "unknown lint id FOO", "lint", "explain", "foo")
+}
+
+func TestLintExplainExamples(t *testing.T) {
+	t.Parallel()
+	for _, linter := range lint.AllLinters {
+		linter := linter
+		t.Run(linter.ID(), func(t *testing.T) {
+			t.Parallel()
+			explanation, err := lint.GetExplanation(linter.ID())
+			require.NoError(t, err)
+			stdout, exitCode := testDoLintExample(t, linter.ID(), explanation, explanation.Bad)
+			assert.Equal(t, 255, exitCode, "failing example passed: %s", stdout)
+			for _, line := range getCleanLines(stdout) {
+				assert.Contains(t, line, ":"+linter.ID()+":")
+			}
+			stdout, exitCode = testDoLintExample(t, linter.ID(), explanation, explanation.Good)
+			assert.Equal(t, 0, exitCode, "passing example failed: %s", stdout)
+			assert.Equal(t, "", stdout)
+		})
+	}
 }
 
 func TestLintConfigDataOverride(t *testing.T) {
@@ -616,6 +952,56 @@ func TestGRPC(t *testing.T) {
 	)
 }
 
+func TestBreakCheck(t *testing.T) {
+	t.Parallel()
+	// the files are the same at HEAD unless they are modified in the working tree
+	assertExact(t, 0, "", "break", "check", "testdata/foo", "--git-branch", "HEAD")
+	assertExact(t, 255, "must set one of git-branch or descriptor-set-path", "break", "check", "testdata/foo")
+	assertExact(t, 255, "must set only one of json or junit", "break", "check", "testdata/foo", "--git-branch", "HEAD", "--json", "--junit")
+	assertExact(
+		t,
+		0,
+		`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="0" failures="0"></testsuites>`,
+		"break", "check", "testdata/foo", "--git-branch", "HEAD", "--junit",
+	)
+}
+
+func TestBreakDescriptorSet(t *testing.T) {
+	t.Parallel()
+	tempDirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tempDirPath) }()
+	descriptorSetPath := filepath.Join(tempDirPath, "descriptor_set.bin")
+	assertExact(t, 0, "", "break", "descriptor-set", "testdata/foo", "--descriptor-set-path", descriptorSetPath)
+	assertExact(t, 0, "", "break", "check", "testdata/foo", "--descriptor-set-path", descriptorSetPath)
+	// files that are no longer part of the checked files only result in warnings
+	assertDo(
+		t,
+		0,
+		`google/protobuf/timestamp.proto:1:1:WARN:Failed to validate file "google/protobuf/timestamp.proto": the file no longer exists.
+		success.proto:1:1:WARN:Failed to validate file "success.proto": the file no longer exists.`,
+		"break", "check", "testdata/foo/bar", "--descriptor-set-path", descriptorSetPath,
+	)
+}
+
+func TestBreakSuggestVersion(t *testing.T) {
+	t.Parallel()
+	assertExact(t, 0, "Suggested version bump: none", "break", "suggest-version", "testdata/foo", "--git-branch", "HEAD")
+	assertExact(t, 255, "must set one of git-branch or descriptor-set-path", "break", "suggest-version", "testdata/foo")
+}
+
+func TestDiffAPI(t *testing.T) {
+	t.Parallel()
+	tempDirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(tempDirPath) }()
+	descriptorSetPath := filepath.Join(tempDirPath, "descriptor_set.bin")
+	assertExact(t, 0, "", "break", "descriptor-set", "testdata/foo", "--descriptor-set-path", descriptorSetPath)
+	assertExact(t, 0, "No changes.", "diff-api", "testdata/foo", "HEAD", descriptorSetPath)
+	assertExact(t, 0, "", "diff-api", "testdata/foo", descriptorSetPath, descriptorSetPath, "--json")
+}
+
 func TestVersion(t *testing.T) {
 	assertRegexp(t, 0, fmt.Sprintf("Version:.*%s\nDefault protoc version:.*%s\n", vars.Version, vars.DefaultProtocVersion), "version")
 }
@@ -625,7 +1011,46 @@ func TestVersionJSON(t *testing.T) {
 }
 
 func TestListAllLintGroups(t *testing.T) {
-	assertExact(t, 0, "all\ndefault", "list-all-lint-groups")
+	assertExact(t, 0, "aip\nall\ndefault", "list-all-lint-groups")
+	assertExact(
+		t,
+		0,
+		"aip\nall\ndefault\nfoo",
+		"list-all-lint-groups",
+		"--config-data",
+		`{"lint":{"groups":[{"name":"foo","add":["ENUM_NAMES_CAMEL_CASE"]}]}}`,
+	)
+}
+
+func TestListLintGroup(t *testing.T) {
+	assertDo(
+		t,
+		0,
+		"ENUM_NAMES_CAMEL_CASE",
+		"list-lint-group",
+		"foo",
+		"--config-data",
+		`{"lint":{"groups":[{"name":"foo","add":["ENUM_NAMES_CAMEL_CASE"]}]}}`,
+	)
+	assertExact(t, 255, "unknown lint group: bar", "list-lint-group", "bar")
+	assertExact(
+		t,
+		255,
+		"lint group all can not have the name of a built-in group",
+		"list-lint-group",
+		"all",
+		"--config-data",
+		`{"lint":{"groups":[{"name":"all","add":["ENUM_NAMES_CAMEL_CASE"]}]}}`,
+	)
+	assertExact(
+		t,
+		255,
+		`lint group foo must start from one of the built-in groups aip, all, default, but was "bar"`,
+		"list-lint-group",
+		"foo",
+		"--config-data",
+		`{"lint":{"groups":[{"name":"foo","group":"bar"}]}}`,
+	)
 }
 
 func TestDescriptorProto(t *testing.T) {
@@ -737,13 +1162,78 @@ func TestListAllLinters(t *testing.T) {
 	assertLinters(t, lint.AllLinters, "lint", "--list-all-linters")
 }
 
+// TestDefaultLinterIDs pins the linters of the default group, so that
+// new linters are not added to it by accident.
+func TestDefaultLinterIDs(t *testing.T) {
+	t.Parallel()
+	ids := make([]string, 0, len(lint.DefaultLinters))
+	for _, linter := range lint.DefaultLinters {
+		ids = append(ids, linter.ID())
+	}
+	sort.Strings(ids)
+	assert.Equal(
+		t,
+		[]string{
+			"COMMENTS_NO_C_STYLE",
+			"ENUMS_NO_ALLOW_ALIAS",
+			"ENUM_FIELD_NAMES_UPPER_SNAKE_CASE",
+			"ENUM_FIELD_PREFIXES",
+			"ENUM_NAMES_CAMEL_CASE",
+			"ENUM_NAMES_CAPITALIZED",
+			"ENUM_NAMES_UPPER_CAMEL_CASE",
+			"ENUM_ZERO_VALUES_INVALID",
+			"FILE_OPTIONS_EQUAL_GO_PACKAGE_PB_SUFFIX",
+			"FILE_OPTIONS_EQUAL_JAVA_MULTIPLE_FILES_TRUE",
+			"FILE_OPTIONS_EQUAL_JAVA_OUTER_CLASSNAME_PROTO_SUFFIX",
+			"FILE_OPTIONS_EQUAL_JAVA_PACKAGE_COM_PREFIX",
+			"FILE_OPTIONS_GO_PACKAGE_NOT_LONG_FORM",
+			"FILE_OPTIONS_GO_PACKAGE_SAME_IN_DIR",
+			"FILE_OPTIONS_JAVA_MULTIPLE_FILES_SAME_IN_DIR",
+			"FILE_OPTIONS_JAVA_PACKAGE_SAME_IN_DIR",
+			"FILE_OPTIONS_REQUIRE_GO_PACKAGE",
+			"FILE_OPTIONS_REQUIRE_JAVA_MULTIPLE_FILES",
+			"FILE_OPTIONS_REQUIRE_JAVA_OUTER_CLASSNAME",
+			"FILE_OPTIONS_REQUIRE_JAVA_PACKAGE",
+			"MESSAGE_FIELD_NAMES_LOWER_CAMEL_CASE",
+			"MESSAGE_FIELD_NAMES_LOWER_SNAKE_CASE",
+			"MESSAGE_NAMES_CAMEL_CASE",
+			"MESSAGE_NAMES_CAPITALIZED",
+			"MESSAGE_NAMES_UPPER_CAMEL_CASE",
+			"ONEOF_NAMES_LOWER_SNAKE_CASE",
+			"PACKAGES_SAME_IN_DIR",
+			"PACKAGE_IS_DECLARED",
+			"PACKAGE_LOWER_CAMEL_CASE",
+			"PACKAGE_LOWER_SNAKE_CASE",
+			"REQUEST_RESPONSE_NAMES_MATCH_SERVICE_RPC",
+			"REQUEST_RESPONSE_TYPES_IN_SAME_FILE",
+			"REQUEST_RESPONSE_TYPES_UNIQUE",
+			"RPC_NAMES_CAMEL_CASE",
+			"RPC_NAMES_CAPITALIZED",
+			"RPC_NAMES_LOWER_CAMEL_CASE",
+			"SERVICE_NAMES_CAMEL_CASE",
+			"SERVICE_NAMES_CAPITALIZED",
+			"SERVICE_NAMES_UPPER_CAMEL_CASE",
+			"SYNTAX_PROTO3",
+			"WKT_DIRECTLY_IMPORTED",
+		},
+		ids,
+	)
+}
+
 func assertLinters(t *testing.T, linters []lint.Linter, args ...string) {
-	linterIDs := make([]string, 0, len(linters))
-	for _, linter := range linters {
-		linterIDs = append(linterIDs, linter.ID())
+	sortedLinters := make([]lint.Linter, len(linters))
+	copy(sortedLinters, linters)
+	sort.Slice(sortedLinters, func(i int, j int) bool { return sortedLinters[i].ID() < sortedLinters[j].ID() })
+	var lines []string
+	for _, linter := range sortedLinters {
+		lines = append(lines, linter.ID())
+		if parameterizedLinter, ok := linter.(lint.ParameterizedLinter); ok {
+			for _, parameter := range parameterizedLinter.Parameters() {
+				lines = append(lines, parameter.Name)
+			}
+		}
 	}
-	sort.Strings(linterIDs)
-	assertDo(t, 0, strings.Join(linterIDs, "\n"), args...)
+	assertDo(t, 0, strings.Join(lines, "\n"), args...)
 }
 
 func assertDoCompileFiles(t *testing.T, expectSuccess bool, asJSON bool, expectedLinePrefixes string, filePaths ...string) {
@@ -863,6 +1353,50 @@ func testDo(t *testing.T, args ...string) (string, int) {
 	return testDoInternal(nil, args...)
 }
 
+// testDoLintExample writes the example of the explanation to a temporary
+// directory with a configuration file that only enables the linter with
+// the parameters of the explanation, and lints it.
+//
+// If the explanation has a previous state of the files, the example is
+// linted against a FileDescriptorSet of it.
+func testDoLintExample(t *testing.T, id string, explanation *lint.Explanation, filePathToData map[string]string) (string, int) {
+	tempDirPath := writeLintExample(t, id, explanation.Parameters, filePathToData)
+	defer func() { _ = os.RemoveAll(tempDirPath) }()
+	if explanation.Previous == nil {
+		return testDo(t, "lint", tempDirPath)
+	}
+	previousTempDirPath := writeLintExample(t, id, explanation.Parameters, explanation.Previous)
+	defer func() { _ = os.RemoveAll(previousTempDirPath) }()
+	descriptorSetPath := filepath.Join(previousTempDirPath, "descriptor_set.bin")
+	_, exitCode := testDo(t, "break", "descriptor-set", previousTempDirPath, "--descriptor-set-path", descriptorSetPath)
+	require.Equal(t, 0, exitCode)
+	return testDo(t, "lint", tempDirPath, "--descriptor-set-path", descriptorSetPath)
+}
+
+// writeLintExample writes the example to a new temporary directory with
+// a configuration file that only enables the linter with the given
+// parameters, and returns the path of the directory.
+//
+// Unused imports are allowed so that they can be reported by UNUSED_IMPORTS.
+func writeLintExample(t *testing.T, id string, parameters map[string]string, filePathToData map[string]string) string {
+	tempDirPath, err := ioutil.TempDir("", "prototool")
+	require.NoError(t, err)
+	configData := fmt.Sprintf("protoc:\n  allow_unused_imports: true\nlint:\n  rules:\n    no_default: true\n    add:\n      - %s\n", id)
+	if len(parameters) > 0 {
+		configData += fmt.Sprintf("    parameters:\n      %s:\n", id)
+		for name, value := range parameters {
+			configData += fmt.Sprintf("        %s: %q\n", name, value)
+		}
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tempDirPath, settings.DefaultConfigFilename), []byte(configData), 0644))
+	for filePath, data := range filePathToData {
+		filePath = filepath.Join(tempDirPath, filepath.FromSlash(filePath))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		require.NoError(t, ioutil.WriteFile(filePath, []byte(strings.TrimSpace(data)+"\n"), 0644))
+	}
+	return tempDirPath
+}
+
 func getCleanLines(output string) []string {
 	var lines []string
 	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
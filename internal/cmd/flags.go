@@ -25,33 +25,38 @@ import (
 )
 
 type flags struct {
-	address        string
-	cachePath      string
-	callTimeout    string
-	configData     string
-	connectTimeout string
-	data           string
-	debug          bool
-	diffMode       bool
-	disableFormat  bool
-	disableLint    bool
-	dryRun         bool
-	fix            bool
-	headers        []string
-	keepaliveTime  string
-	json           bool
-	listAllLinters bool
-	listLinters    bool
-	lintMode       bool
-	method         string
-	overwrite      bool
-	pkg            string
-	printFields    string
-	protocBinPath  string
-	protocWKTPath  string
-	protocURL      string
-	stdin          bool
-	uncomment      bool
+	address           string
+	cachePath         string
+	callTimeout       string
+	configData        string
+	connectTimeout    string
+	data              string
+	debug             bool
+	descriptorSetPath string
+	diffMode          bool
+	disableFormat     bool
+	disableLint       bool
+	dryRun            bool
+	fix               bool
+	generateBaseline  bool
+	gitBranch         string
+	headers           []string
+	keepaliveTime     string
+	json              bool
+	junit             bool
+	listAllLinters    bool
+	listLinters       bool
+	lintMode          bool
+	method            string
+	overwrite         bool
+	pkg               string
+	printFields       string
+	protocBinPath     string
+	protocWKTPath     string
+	protocURL         string
+	stdin             bool
+	strict            bool
+	uncomment         bool
 }
 
 func (f *flags) bindAddress(flagSet *pflag.FlagSet) {
@@ -82,6 +87,10 @@ func (f *flags) bindDebug(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.debug, "debug", false, "Run in debug mode, which will print out debug logging.")
 }
 
+func (f *flags) bindDescriptorSetPath(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.descriptorSetPath, "descriptor-set-path", "", "The path to the serialized FileDescriptorSet.")
+}
+
 func (f *flags) bindDiffMode(flagSet *pflag.FlagSet) {
 	flagSet.BoolVarP(&f.diffMode, "diff", "d", false, "Write a diff instead of writing the formatted file to stdout.")
 }
@@ -98,6 +107,10 @@ func (f *flags) bindDryRun(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.dryRun, "dry-run", false, "Print the protoc commands that would have been run without actually running them.")
 }
 
+func (f *flags) bindGitBranch(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.gitBranch, "git-branch", "", "The git branch or other ref to check against. Exactly one of --git-branch or --descriptor-set-path must be set.")
+}
+
 func (f *flags) bindHeaders(flagSet *pflag.FlagSet) {
 	flagSet.StringSliceVarP(&f.headers, "header", "H", []string{}, "Additional request headers in 'name:value' format.")
 }
@@ -110,6 +123,26 @@ func (f *flags) bindJSON(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.json, "json", false, "Output as JSON.")
 }
 
+func (f *flags) bindJUnit(flagSet *pflag.FlagSet) {
+	flagSet.BoolVar(&f.junit, "junit", false, "Output as a JUnit XML report.")
+}
+
+func (f *flags) bindLintFix(flagSet *pflag.FlagSet) {
+	flagSet.BoolVarP(&f.fix, "fix", "f", false, "Apply the suggested fixes for lint failures to the files and format them, then print the remaining lint failures.")
+}
+
+func (f *flags) bindGenerateBaseline(flagSet *pflag.FlagSet) {
+	flagSet.BoolVar(&f.generateBaseline, "generate-baseline", false, "Write the current lint failures to the baseline file set in the lint section of the configuration file instead of printing them.")
+}
+
+func (f *flags) bindLintDescriptorSetPath(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.descriptorSetPath, "descriptor-set-path", "", "The path to a FileDescriptorSet written by \"prototool break descriptor-set\" to compare against for lint rules that check deleted elements, such as DELETED_FIELDS_RESERVED.")
+}
+
+func (f *flags) bindLintGitBranch(flagSet *pflag.FlagSet) {
+	flagSet.StringVar(&f.gitBranch, "git-branch", "", "The git branch or other ref to compare against for lint rules that check deleted elements, such as DELETED_FIELDS_RESERVED.")
+}
+
 func (f *flags) bindLintMode(flagSet *pflag.FlagSet) {
 	flagSet.BoolVarP(&f.lintMode, "lint", "l", false, "Write a lint error saying that the file is not formatted instead of writing the formatted file to stdout.")
 }
@@ -122,6 +155,10 @@ func (f *flags) bindListLinters(flagSet *pflag.FlagSet) {
 	flagSet.BoolVar(&f.listLinters, "list-linters", false, "List the configured linters instead of running lint.")
 }
 
+func (f *flags) bindLintStrict(flagSet *pflag.FlagSet) {
+	flagSet.BoolVar(&f.strict, "strict", false, "Exit with a non-zero exit code for lint failures with the severity warning in addition to error.")
+}
+
 func (f *flags) bindMethod(flagSet *pflag.FlagSet) {
 	flagSet.StringVar(&f.method, "method", "", "The GRPC method to call in the form package.Service/Method. This is required.")
 }
@@ -135,7 +172,7 @@ func (f *flags) bindPackage(flagSet *pflag.FlagSet) {
 }
 
 func (f *flags) bindPrintFields(flagSet *pflag.FlagSet) {
-	flagSet.StringVar(&f.printFields, "print-fields", "filename:line:column:message", "The colon-separated fields to print out on error.")
+	flagSet.StringVar(&f.printFields, "print-fields", "filename:line:column:message", "The colon-separated fields to print out on error, from filename, line, column, id, message, and severity. With the default fields, the severity of lint failures that are not errors is printed before the message.")
 }
 
 func (f *flags) bindProtocURL(flagSet *pflag.FlagSet) {
@@ -68,6 +68,62 @@ var (
 		},
 	}
 
+	breakCheckCmdTemplate = &cmdTemplate{
+		Use:   "check [dirOrFile]",
+		Short: "Check for breaking changes compared to the state of the files at a git branch or in a FileDescriptorSet.",
+		Long:  `The working tree is compiled and compared for backwards-incompatible changes against either the same files at the given git branch, or a FileDescriptorSet written by "prototool break descriptor-set". Changes that break the wire format or generated code result in a non-zero exit code. The git branch can be any local ref that resolves to a commit, such as a branch name, tag, or commit hash.`,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.BreakCheck(args, flags.gitBranch, flags.descriptorSetPath)
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindConfigData(flagSet)
+			flags.bindDescriptorSetPath(flagSet)
+			flags.bindGitBranch(flagSet)
+			flags.bindJSON(flagSet)
+			flags.bindJUnit(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+		},
+	}
+
+	breakDescriptorSetCmdTemplate = &cmdTemplate{
+		Use:   "descriptor-set [dirOrFile]",
+		Short: "Write a FileDescriptorSet to check for breaking changes against with \"prototool break check\".",
+		Long:  `The FileDescriptorSet contains all files and their imports, and includes source code info. This is intended to be used to store a snapshot of a released API.`,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.BreakDescriptorSet(args, flags.descriptorSetPath)
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindConfigData(flagSet)
+			flags.bindDescriptorSetPath(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+		},
+	}
+
+	breakSuggestVersionCmdTemplate = &cmdTemplate{
+		Use:   "suggest-version [dirOrFile]",
+		Short: "Suggest a semantic version bump compared to the state of the files at a git branch or in a FileDescriptorSet.",
+		Long:  `The working tree is compiled and compared against either the same files at the given git branch, or a FileDescriptorSet written by "prototool break descriptor-set", as with "prototool break check". A major version bump is suggested if there are breaking changes, a minor version bump if types such as messages, fields, or methods were added, and a patch version bump for any other changes. The breaking changes, additions, and other changes are printed for each package.`,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.BreakSuggestVersion(args, flags.gitBranch, flags.descriptorSetPath)
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindConfigData(flagSet)
+			flags.bindDescriptorSetPath(flagSet)
+			flags.bindGitBranch(flagSet)
+			flags.bindJSON(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+		},
+	}
+
 	cleanCmdTemplate = &cmdTemplate{
 		Use:   "clean",
 		Short: "Delete the cache.",
@@ -178,6 +234,23 @@ If Vim integration is set up, files will be generated when you open a new Protob
 		},
 	}
 
+	diffAPICmdTemplate = &cmdTemplate{
+		Use:   "diff-api [dirOrFile] from to",
+		Short: "Print a changelog of the API changes between two git refs or FileDescriptorSets.",
+		Long:  `Each of from and to is either the path to a FileDescriptorSet written by "prototool break descriptor-set", or a git ref of the enclosing git repository such as a branch name, tag, or commit hash, in which case the files at the ref are compiled. All added, removed, and changed services, methods, messages, fields, enums, and enum values are printed as Markdown, or as JSON with --json. This includes changes to comments.`,
+		Args:  cobra.RangeArgs(2, 3),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.DiffAPI(args)
+		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindConfigData(flagSet)
+			flags.bindJSON(flagSet)
+			flags.bindProtocURL(flagSet)
+			flags.bindProtocBinPath(flagSet)
+			flags.bindProtocWKTPath(flagSet)
+		},
+	}
+
 	downloadCmdTemplate = &cmdTemplate{
 		Use:   "download",
 		Short: "Download the protobuf artifacts to a cache.",
@@ -373,19 +446,34 @@ $ cat input.json | prototool grpc example \
 		Long:  `The default rule set follows the Style Guide at https://github.com/uber/prototool/blob/master/etc/style/uber/uber.proto. You can add or exclude lint rules in your configuration file. The default rule set is very strict and is meant to enforce consistent development patterns.`,
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(runner exec.Runner, args []string, flags *flags) error {
-			return runner.Lint(args, flags.listAllLinters, flags.listLinters)
+			return runner.Lint(args, flags.listAllLinters, flags.listLinters, flags.generateBaseline, flags.fix, flags.strict, flags.gitBranch, flags.descriptorSetPath)
 		},
 		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
 			flags.bindConfigData(flagSet)
+			flags.bindLintDescriptorSetPath(flagSet)
+			flags.bindGenerateBaseline(flagSet)
+			flags.bindLintGitBranch(flagSet)
 			flags.bindJSON(flagSet)
+			flags.bindLintFix(flagSet)
 			flags.bindListAllLinters(flagSet)
 			flags.bindListLinters(flagSet)
+			flags.bindLintStrict(flagSet)
 			flags.bindProtocURL(flagSet)
 			flags.bindProtocBinPath(flagSet)
 			flags.bindProtocWKTPath(flagSet)
 		},
 	}
 
+	lintExplainCmdTemplate = &cmdTemplate{
+		Use:   "explain id",
+		Short: "Explain the given lint rule.",
+		Long:  `Prints the purpose and rationale of the lint rule, whether it is in the default group, and an example that fails and an example that passes the rule.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(runner exec.Runner, args []string, flags *flags) error {
+			return runner.LintExplain(args[0])
+		},
+	}
+
 	listAllLintGroupsCmdTemplate = &cmdTemplate{
 		Use:   "list-all-lint-groups",
 		Short: "List all the available lint groups.",
@@ -393,6 +481,9 @@ $ cat input.json | prototool grpc example \
 		Run: func(runner exec.Runner, args []string, flags *flags) error {
 			return runner.ListAllLintGroups()
 		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindConfigData(flagSet)
+		},
 	}
 
 	listLintGroupCmdTemplate = &cmdTemplate{
@@ -402,6 +493,9 @@ $ cat input.json | prototool grpc example \
 		Run: func(runner exec.Runner, args []string, flags *flags) error {
 			return runner.ListLintGroup(args[0])
 		},
+		BindFlags: func(flagSet *pflag.FlagSet, flags *flags) {
+			flags.bindConfigData(flagSet)
+		},
 	}
 
 	serviceDescriptorProtoCmdTemplate = &cmdTemplate{
@@ -520,6 +614,12 @@ func getRunner(stdin io.Reader, stdout io.Writer, stderr io.Writer, flags *flags
 			exec.RunnerWithJSON(),
 		)
 	}
+	if flags.junit {
+		runnerOptions = append(
+			runnerOptions,
+			exec.RunnerWithJUnit(),
+		)
+	}
 	if flags.protocBinPath != "" {
 		runnerOptions = append(
 			runnerOptions,
@@ -0,0 +1,19 @@
+#!/bin/sh
+
+# A lint plugin that reports the messages named Bar, if the
+# forbidden_message parameter is Bar. A real plugin would
+# decode the file_descriptor_set in the request instead.
+
+request="$(cat)"
+case "${request}" in
+  *'"files":["foo.proto"]'*'"forbidden_message":"Bar"'*) ;;
+  *) echo "unexpected request" >&2; exit 1 ;;
+esac
+cat <<END
+{
+  "failures": [
+    {"filename": "foo.proto", "line": 12, "column": 3, "lint_id": "forbidden_message", "message": "Message Foo.Bar is forbidden."},
+    {"filename": "foo.proto", "line": 15, "column": 1, "lint_id": "forbidden_message", "message": "Message Bar is forbidden."}
+  ]
+}
+END
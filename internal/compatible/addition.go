@@ -0,0 +1,76 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import "fmt"
+
+// Addition represents a backward-compatible addition, such
+// as a new message, field, or method.
+type Addition struct {
+	// The full path to the filename, as in foo/bar.proto.
+	Filename string `json:"filename"`
+	Line     int32  `json:"line"`
+	Column   int32  `json:"column"`
+	Message  string `json:"message"`
+}
+
+// String returns a string representation of the Addition type.
+// The string is of the following form,
+//
+//  $(FILENAME):$(LINE):$(COLUMN):$(MESSAGE)
+//
+//  For example,
+//   "foo.proto:5:10:Field "foo" (1) was added."
+func (a Addition) String() string {
+	return fmt.Sprintf("%s:%d:%d:%s", a.Filename, a.Line, a.Column, a.Message)
+}
+
+// Additions is defined in order to sort a slice of Additions.
+type Additions []Addition
+
+// Less defines the precedence for sorting a slice of Additions.
+// The order is as follows,
+//  - Filename
+//  - Line
+//  - Column
+//  - Message
+func (as Additions) Less(i, j int) bool {
+	if as[i].Filename != as[j].Filename {
+		return as[i].Filename < as[j].Filename
+	}
+	if as[i].Line != as[j].Line {
+		return as[i].Line < as[j].Line
+	}
+	if as[i].Column != as[j].Column {
+		return as[i].Column < as[j].Column
+	}
+	return as[i].Message < as[j].Message
+}
+
+// Len returns the length of this slice of Additions.
+func (as Additions) Len() int {
+	return len(as)
+}
+
+// Swap swaps two elements in the slice of Additions.
+func (as Additions) Swap(i, j int) {
+	as[i], as[j] = as[j], as[i]
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdditions(t *testing.T) {
+	newFileDescriptorProto := func(filename string, pkg string, fieldNames ...string) *descriptor.FileDescriptorProto {
+		md := &descriptor.DescriptorProto{Name: proto.String("Foo")}
+		for i, fieldName := range fieldNames {
+			md.Field = append(md.Field, &descriptor.FieldDescriptorProto{
+				Name:   proto.String(fieldName),
+				Number: proto.Int32(int32(i + 1)),
+			})
+		}
+		return &descriptor.FileDescriptorProto{
+			Name:        proto.String(filename),
+			Package:     proto.String(pkg),
+			MessageType: []*descriptor.DescriptorProto{md},
+		}
+	}
+	newFileDescriptorSet := func(fds ...*descriptor.FileDescriptorProto) *descriptor.FileDescriptorSet {
+		return &descriptor.FileDescriptorSet{File: fds}
+	}
+	tests := []struct {
+		desc     string
+		original *descriptor.FileDescriptorSet
+		updated  *descriptor.FileDescriptorSet
+		options  []CheckOption
+		want     []string
+	}{
+		{
+			desc:     "No additions",
+			original: newFileDescriptorSet(newFileDescriptorProto("foo.proto", "foo", "one")),
+			updated:  newFileDescriptorSet(newFileDescriptorProto("foo.proto", "foo", "one")),
+		},
+		{
+			desc:     "Removals are not additions",
+			original: newFileDescriptorSet(newFileDescriptorProto("foo.proto", "foo", "one", "two")),
+			updated:  newFileDescriptorSet(newFileDescriptorProto("foo.proto", "foo", "one")),
+		},
+		{
+			desc:     "Added field",
+			original: newFileDescriptorSet(newFileDescriptorProto("foo.proto", "foo", "one")),
+			updated:  newFileDescriptorSet(newFileDescriptorProto("foo.proto", "foo", "one", "two")),
+			want:     []string{`foo.proto:1:1:Field "two" (2) was added.`},
+		},
+		{
+			desc:     "Added file",
+			original: newFileDescriptorSet(newFileDescriptorProto("foo.proto", "foo", "one")),
+			updated: newFileDescriptorSet(
+				newFileDescriptorProto("foo.proto", "foo", "one"),
+				newFileDescriptorProto("bar.proto", "bar"),
+			),
+			want: []string{`bar.proto:0:0:File "bar.proto" was added.`},
+		},
+		{
+			desc:     "Ignored package",
+			original: newFileDescriptorSet(newFileDescriptorProto("foo.proto", "foo", "one")),
+			updated:  newFileDescriptorSet(newFileDescriptorProto("foo.proto", "foo", "one", "two")),
+			options:  []CheckOption{CheckWithIgnorePackages("foo")},
+		},
+		{
+			desc:     "Moved file with package scope",
+			original: newFileDescriptorSet(newFileDescriptorProto("foo.proto", "foo", "one")),
+			updated:  newFileDescriptorSet(newFileDescriptorProto("bar.proto", "foo", "one", "two")),
+			options:  []CheckOption{CheckWithPackageScope()},
+			want:     []string{`bar.proto:1:1:Field "two" (2) was added.`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var got []string
+			for _, addition := range CheckAdditions(tt.original, tt.updated, tt.options...) {
+				got = append(got, addition.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -74,6 +74,7 @@ func (c *fileChecker) checkRemovedItems(original, updated descriptorProtoGroup,
 				"%s was removed.",
 				o.Type(),
 			)
+			c.addRemoved(o.Path().Target(id), o)
 		}
 	}
 }
@@ -106,3 +107,17 @@ func (c *fileChecker) checkUpdatedAttribute(typ descriptorProto, severity Severi
 		)
 	}
 }
+
+// findTarget returns the path to the given target of the descriptorProto,
+// or to the fallback target if the given target has no location. This is
+// the case for attributes that are not explicitly set in the file.
+func (c *fileChecker) findTarget(typ descriptorProto, fallback location.ID, ids ...location.ID) location.Path {
+	path := typ.Path()
+	for _, id := range ids {
+		path = path.Target(id)
+	}
+	if _, ok := c.finder.Find(path); ok {
+		return path
+	}
+	return typ.Path().Target(fallback)
+}
@@ -22,7 +22,9 @@ package compatible
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/uber/prototool/internal/location"
@@ -33,6 +35,9 @@ type fileChecker struct {
 	filename string
 	finder   *location.Finder
 	errors   Errors
+	// removed collects the types that were removed. These are
+	// the additions when checking in the reverse direction.
+	removed Additions
 }
 
 func newFileChecker(from *descriptor.FileDescriptorProto) *fileChecker {
@@ -42,24 +47,153 @@ func newFileChecker(from *descriptor.FileDescriptorProto) *fileChecker {
 	}
 }
 
+// unstablePackageVersionRegexp matches the last component of packages
+// such as foo.v1alpha1 or foo.v2beta.
+var unstablePackageVersionRegexp = regexp.MustCompile(`^v\d+(alpha|beta)\d*$`)
+
+// CheckOption is an option for Check.
+type CheckOption func(*checkOptions)
+
+// CheckWithIgnorePackages returns a CheckOption that ignores
+// the files with one of the given packages.
+func CheckWithIgnorePackages(pkgs ...string) CheckOption {
+	return func(checkOptions *checkOptions) {
+		for _, pkg := range pkgs {
+			checkOptions.ignorePackages[pkg] = struct{}{}
+		}
+	}
+}
+
+// CheckWithIgnoreFiles returns a CheckOption that ignores the
+// files with one of the given names, as in foo/bar.proto.
+func CheckWithIgnoreFiles(filenames ...string) CheckOption {
+	return func(checkOptions *checkOptions) {
+		for _, filename := range filenames {
+			checkOptions.ignoreFiles[filename] = struct{}{}
+		}
+	}
+}
+
+// CheckWithIgnoreUnstablePackages returns a CheckOption that ignores
+// the files with packages that have an alpha or beta version suffix,
+// as in foo.v1alpha1 or foo.v1beta1.
+func CheckWithIgnoreUnstablePackages() CheckOption {
+	return func(checkOptions *checkOptions) {
+		checkOptions.ignoreUnstablePackages = true
+	}
+}
+
+// CheckWithPackageScope returns a CheckOption that compares types by
+// their fully-qualified name within their package, instead of comparing
+// files by name. Moving a type between files of the same package is then
+// not a breaking change.
+func CheckWithPackageScope() CheckOption {
+	return func(checkOptions *checkOptions) {
+		checkOptions.packageScope = true
+	}
+}
+
+type checkOptions struct {
+	packageScope           bool
+	ignorePackages         map[string]struct{}
+	ignoreFiles            map[string]struct{}
+	ignoreUnstablePackages bool
+}
+
+func newCheckOptions(options ...CheckOption) *checkOptions {
+	checkOptions := &checkOptions{
+		ignorePackages: make(map[string]struct{}),
+		ignoreFiles:    make(map[string]struct{}),
+	}
+	for _, option := range options {
+		option(checkOptions)
+	}
+	return checkOptions
+}
+
+// shouldIgnore returns true if errors for the given file should be ignored.
+func (o *checkOptions) shouldIgnore(fd *descriptor.FileDescriptorProto) bool {
+	if _, ok := o.ignoreFiles[fd.GetName()]; ok {
+		return true
+	}
+	if _, ok := o.ignorePackages[fd.GetPackage()]; ok {
+		return true
+	}
+	return o.ignoreUnstablePackages && isUnstablePackage(fd.GetPackage())
+}
+
+// isUnstablePackage returns true if the package has an alpha
+// or beta version suffix.
+func isUnstablePackage(pkg string) bool {
+	return unstablePackageVersionRegexp.MatchString(pkg[strings.LastIndex(pkg, ".")+1:])
+}
+
 // Check determines whether "to" is backward-compatible with "from".
-func Check(from, to *descriptor.FileDescriptorSet) []Error {
+//
+// A file is ignored if either its original or updated state is ignored
+// by the given CheckOptions.
+func Check(from, to *descriptor.FileDescriptorSet, options ...CheckOption) []Error {
+	errs, _ := checkFileDescriptorSets(from, to, newCheckOptions(options...))
+	return errs
+}
+
+// CheckAdditions returns the types that were added to "to" compared to "from",
+// such as files, messages, fields, enum values, services and methods.
+//
+// A type is added if it would be removed when going from "to" back to
+// "from", so the same CheckOptions as for Check apply.
+func CheckAdditions(from, to *descriptor.FileDescriptorSet, options ...CheckOption) []Addition {
+	_, additions := checkFileDescriptorSets(to, from, newCheckOptions(options...))
+	return additions
+}
+
+// checkFileDescriptorSets returns the Errors for the changes from "from" to
+// "to", and the types that were removed from "from" in the form of Additions.
+func checkFileDescriptorSets(from, to *descriptor.FileDescriptorSet, checkOptions *checkOptions) (Errors, Additions) {
 	var (
 		basePath location.Path
 		errs     Errors
+		removed  Additions
 	)
 
+	if checkOptions.packageScope {
+		pkgs := newPackages(to)
+		fs := newFileSet(to)
+		for _, original := range from.GetFile() {
+			if checkOptions.shouldIgnore(original) {
+				continue
+			}
+			updatedFile := fs[original.GetName()]
+			if updatedFile != nil && checkOptions.shouldIgnore(updatedFile.descriptor) {
+				updatedFile = nil
+			}
+			c := newFileChecker(original)
+			errs = append(errs, c.checkPackageFile(newFile(original, basePath), updatedFile, pkgs[original.GetPackage()])...)
+			removed = append(removed, c.removed...)
+		}
+		sort.Sort(errs)
+		sort.Sort(removed)
+		return errs, removed
+	}
+
 	fs := newFileSet(from)
 	for _, updated := range to.GetFile() {
 		if original, ok := fs[updated.GetName()]; ok {
+			if checkOptions.shouldIgnore(original.descriptor) || checkOptions.shouldIgnore(updated) {
+				continue
+			}
 			c := newFileChecker(original.descriptor)
 			errs = append(errs, c.checkFile(original, newFile(updated, basePath))...)
+			removed = append(removed, c.removed...)
 		}
 	}
 
 	fs = newFileSet(to)
 	for _, original := range from.GetFile() {
 		filename := original.GetName()
+		if checkOptions.shouldIgnore(original) {
+			continue
+		}
 		if _, ok := fs[filename]; !ok {
 			errs = append(
 				errs,
@@ -69,11 +203,19 @@ func Check(from, to *descriptor.FileDescriptorSet) []Error {
 					Message:  fmt.Sprintf("Failed to validate file %q: the file no longer exists.", filename),
 				},
 			)
+			removed = append(
+				removed,
+				Addition{
+					Filename: filename,
+					Message:  fmt.Sprintf("File %q was added.", filename),
+				},
+			)
 		}
 	}
 
 	sort.Sort(errs)
-	return errs
+	sort.Sort(removed)
+	return errs, removed
 }
 
 // AddErrorf adds an Error to the fileChecker using the given path and formatted message.
@@ -92,3 +234,16 @@ func (c *fileChecker) AddErrorf(path location.Path, severity Severity, format st
 	}
 	c.errors = append(c.errors, err)
 }
+
+// addRemoved records that the descriptorProto at the given path was removed.
+func (c *fileChecker) addRemoved(path location.Path, typ descriptorProto) {
+	loc, _ := c.finder.Find(path)
+
+	addition := Addition{
+		Filename: c.filename,
+		Line:     loc.Span.Line(),
+		Column:   loc.Span.Col(),
+		Message:  fmt.Sprintf("%s was added.", typ.Type()),
+	}
+	c.removed = append(c.removed, addition)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
 )
 
 const _testFilename = "test.proto"
@@ -69,3 +70,71 @@ func TestCheck(t *testing.T) {
 		//}
 	})
 }
+
+func TestCheckIgnores(t *testing.T) {
+	newFileDescriptorSet := func(pkg string, messageNames ...string) *descriptor.FileDescriptorSet {
+		fd := &descriptor.FileDescriptorProto{
+			Name:    proto.String(_testFilename),
+			Package: proto.String(pkg),
+		}
+		for _, messageName := range messageNames {
+			fd.MessageType = append(fd.MessageType, &descriptor.DescriptorProto{Name: proto.String(messageName)})
+		}
+		return &descriptor.FileDescriptorSet{File: []*descriptor.FileDescriptorProto{fd}}
+	}
+	tests := []struct {
+		desc    string
+		pkg     string
+		options []CheckOption
+		wantLen int
+	}{
+		{
+			desc:    "No options",
+			pkg:     "foo.v1",
+			wantLen: 1,
+		},
+		{
+			desc:    "Ignored package",
+			pkg:     "foo.v1",
+			options: []CheckOption{CheckWithIgnorePackages("foo.v1")},
+		},
+		{
+			desc:    "Other ignored package",
+			pkg:     "foo.v1",
+			options: []CheckOption{CheckWithIgnorePackages("foo")},
+			wantLen: 1,
+		},
+		{
+			desc:    "Ignored file",
+			pkg:     "foo.v1",
+			options: []CheckOption{CheckWithIgnoreFiles(_testFilename)},
+		},
+		{
+			desc:    "Stable package with unstable packages ignored",
+			pkg:     "foo.v1",
+			options: []CheckOption{CheckWithIgnoreUnstablePackages()},
+			wantLen: 1,
+		},
+		{
+			desc:    "Alpha package with unstable packages ignored",
+			pkg:     "foo.v1alpha1",
+			options: []CheckOption{CheckWithIgnoreUnstablePackages()},
+		},
+		{
+			desc:    "Beta package with unstable packages ignored",
+			pkg:     "v2beta",
+			options: []CheckOption{CheckWithIgnoreUnstablePackages()},
+		},
+		{
+			desc:    "Alpha package without unstable packages ignored",
+			pkg:     "foo.v1alpha1",
+			wantLen: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := Check(newFileDescriptorSet(tt.pkg, "Foo", "Bar"), newFileDescriptorSet(tt.pkg, "Foo"), tt.options...)
+			assert.Len(t, errs, tt.wantLen)
+		})
+	}
+}
@@ -42,9 +42,11 @@ func (es enums) Items() map[string]descriptorProto {
 
 // enum represents a *descriptor.EnumDescriptorProto.
 type enum struct {
-	path   location.Path
-	name   string
-	values enumValues
+	path     location.Path
+	name     string
+	values   enumValues
+	reserved *reserved
+	options  []option
 }
 
 var _ descriptorProto = (*enum)(nil)
@@ -77,6 +79,7 @@ var _ descriptorProto = (*enumValue)(nil)
 func (e *enumValue) Name() string        { return e.name }
 func (e *enumValue) Path() location.Path { return e.path }
 func (e *enumValue) Type() string        { return fmt.Sprintf("Enum value %q (%d)", e.name, e.number) }
+func (e *enumValue) Number() int32       { return e.number }
 
 // hasEnums is implemented by both the *descriptor.FileDescriptorProto
 // and *descriptor.DescriptorProto types.
@@ -99,9 +102,11 @@ func getEnums(d hasEnums, p location.Path, id location.ID) enums {
 
 func newEnum(ed *descriptor.EnumDescriptorProto, p location.Path) *enum {
 	return &enum{
-		path:   p,
-		name:   ed.GetName(),
-		values: getEnumValues(ed, p),
+		path:     p,
+		name:     ed.GetName(),
+		values:   getEnumValues(ed, p),
+		reserved: newEnumReserved(ed, p),
+		options:  getEnumOptions(ed.GetOptions()),
 	}
 }
 
@@ -123,14 +128,20 @@ func newEnumValue(vd *descriptor.EnumValueDescriptorProto, p location.Path) *enu
 
 // checkEnums verifies that,
 //  - None of the enum types were removed.
-//  - None of an enum's values/numbers were removed.
+//  - None of an enum's values/numbers were removed, unless
+//    both the number and the name are reserved.
 //  - None of an enum's value names were updated.
+//  - None of an enum's reserved numbers were un-reserved.
+//  - None of an enum's reserved names were reused.
+//  - None of an enum's options were inappropriately updated.
 func (c *fileChecker) checkEnums(original, updated enums) {
 	c.checkRemovedItems(original, updated, location.Name)
 	for i, ue := range updated {
 		oe, ok := original[i]
 		if ok {
-			c.checkRemovedItems(oe.values, ue.values, location.EnumValueNumber)
+			c.checkRemovedNumberedItems(oe.values, ue.values, ue.reserved, location.EnumValueNumber)
+			c.checkReserved(oe.reserved, ue.reserved, ue.values)
+			c.checkOptions(oe, location.EnumOption, location.Name, oe.options, ue.options)
 		}
 	}
 }
@@ -35,6 +35,20 @@ const (
 	Wire   Severity = "wire"
 )
 
+var _severityToLevel = map[Severity]int{
+	Warn:   0,
+	Source: 1,
+	Wire:   2,
+}
+
+// IsAtLeast returns true if the Severity is at least as
+// significant as the given Severity.
+//
+// The order of significance is warn, source, wire.
+func (s Severity) IsAtLeast(severity Severity) bool {
+	return _severityToLevel[s] >= _severityToLevel[severity]
+}
+
 // Error represents an API-compatibility error.
 type Error struct {
 	// The full path to the filename, as in foo/bar.proto.
@@ -68,3 +68,15 @@ func TestError(t *testing.T) {
 		})
 	}
 }
+
+func TestSeverityIsAtLeast(t *testing.T) {
+	assert.True(t, Wire.IsAtLeast(Wire))
+	assert.True(t, Wire.IsAtLeast(Source))
+	assert.True(t, Wire.IsAtLeast(Warn))
+	assert.False(t, Source.IsAtLeast(Wire))
+	assert.True(t, Source.IsAtLeast(Source))
+	assert.True(t, Source.IsAtLeast(Warn))
+	assert.False(t, Warn.IsAtLeast(Wire))
+	assert.False(t, Warn.IsAtLeast(Source))
+	assert.True(t, Warn.IsAtLeast(Warn))
+}
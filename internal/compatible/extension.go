@@ -0,0 +1,109 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"strconv"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/location"
+)
+
+// extensions are keyed by name rather than by number, so
+// that renumbered extensions can be detected.
+type extensions map[string]*field
+
+var _ descriptorProtoGroup = (extensions)(nil)
+
+func (es extensions) Items() map[string]descriptorProto {
+	items := make(map[string]descriptorProto)
+	for i, e := range es {
+		items[i] = e
+	}
+	return items
+}
+
+func getExtensions(fds []*descriptor.FieldDescriptorProto, p location.Path, id location.ID, syntax string) extensions {
+	extensions := make(extensions, len(fds))
+	for i, fd := range fds {
+		extensions[fd.GetName()] = newField(fd, nil, p.Scope(id, i), syntax)
+	}
+	return extensions
+}
+
+// checkExtensions verifies that,
+//  - None of the extensions were removed.
+//  - None of the extensions were renumbered.
+//  - None of the extensions' extendees were updated.
+//  - None of the extensions were otherwise inappropriately
+//    updated, as for fields.
+func (c *fileChecker) checkExtensions(original, updated extensions) {
+	c.checkRemovedItems(original, updated, location.Name)
+	for i, ue := range updated {
+		if oe, ok := original[i]; ok {
+			c.checkUpdatedAttribute(
+				oe,
+				Wire,
+				"number",
+				strconv.Itoa(int(oe.number)),
+				strconv.Itoa(int(ue.number)),
+				location.FieldNumber,
+			)
+			c.checkUpdatedAttribute(
+				oe,
+				Wire,
+				"extendee",
+				oe.extendee,
+				ue.extendee,
+				location.FieldExtendee,
+			)
+			c.checkField(oe, ue)
+		}
+	}
+}
+
+// checkExtensionRanges verifies that none of the numbers within
+// the original message's extension ranges were removed from the
+// updated message's extension ranges.
+func (c *fileChecker) checkExtensionRanges(original, updated *message) {
+	for i, er := range original.extensionRanges {
+		for _, removed := range er.subtract(updated.extensionRanges) {
+			if removed.start == removed.end {
+				c.AddErrorf(
+					original.path.Scope(location.ExtensionRange, i),
+					Wire,
+					"%s extension number %d was removed from the extension ranges.",
+					original.Type(),
+					removed.start,
+				)
+				continue
+			}
+			c.AddErrorf(
+				original.path.Scope(location.ExtensionRange, i),
+				Wire,
+				"%s extension numbers %d to %d were removed from the extension ranges.",
+				original.Type(),
+				removed.start,
+				removed.end,
+			)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber/prototool/internal/location"
+)
+
+func TestExtensions(t *testing.T) {
+	tests := []struct {
+		desc     string
+		original extensions
+		updated  extensions
+		err      string
+	}{
+		{
+			desc:     "Valid update",
+			original: extensions{"foo": &field{number: 100, name: "foo", extendee: "Bar"}},
+			updated:  extensions{"foo": &field{number: 100, name: "foo", extendee: "Bar"}, "baz": &field{number: 101, name: "baz", extendee: "Bar"}},
+		},
+		{
+			desc:     "Removed extension",
+			original: extensions{"foo": &field{number: 100, name: "foo", extendee: "Bar"}},
+			updated:  extensions{},
+			err:      `test.proto:1:1:wire:Extension "foo" (100) was removed.`,
+		},
+		{
+			desc:     "Renumbered extension",
+			original: extensions{"foo": &field{number: 100, name: "foo", extendee: "Bar"}},
+			updated:  extensions{"foo": &field{number: 101, name: "foo", extendee: "Bar"}},
+			err:      `test.proto:1:1:wire:Extension "foo" (100) had its number updated from "100" to "101".`,
+		},
+		{
+			desc:     "Updated extendee",
+			original: extensions{"foo": &field{number: 100, name: "foo", extendee: "Bar"}},
+			updated:  extensions{"foo": &field{number: 100, name: "foo", extendee: "Baz"}},
+			err:      `test.proto:1:1:wire:Extension "foo" (100) had its extendee updated from "Bar" to "Baz".`,
+		},
+		{
+			desc:     "Updated type",
+			original: extensions{"foo": &field{number: 100, name: "foo", extendee: "Bar", typeName: "fixed32"}},
+			updated:  extensions{"foo": &field{number: 100, name: "foo", extendee: "Bar", typeName: "string"}},
+			err:      `test.proto:1:1:wire:Extension "foo" (100) had its type updated from "fixed32" to "string".`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := newTestChecker(t)
+			fn := func(o, u descriptorProtoGroup) {
+				c.checkExtensions(o.(extensions), u.(extensions))
+			}
+			check(t, c, fn, tt.original, tt.updated, tt.err)
+		})
+	}
+}
+
+func TestExtensionRanges(t *testing.T) {
+	newTestMessages := func(extensionRanges ...[2]int32) messages {
+		md := &descriptor.DescriptorProto{
+			Name: proto.String("Foo"),
+		}
+		for _, extensionRange := range extensionRanges {
+			md.ExtensionRange = append(md.ExtensionRange, &descriptor.DescriptorProto_ExtensionRange{
+				Start: proto.Int32(extensionRange[0]),
+				End:   proto.Int32(extensionRange[1]),
+			})
+		}
+		return messages{"Foo": newMessage(md, nil, "")}
+	}
+	tests := []struct {
+		desc     string
+		original messages
+		updated  messages
+		errs     []string
+	}{
+		{
+			desc:     "Extended extension range",
+			original: newTestMessages([2]int32{100, 200}),
+			updated:  newTestMessages([2]int32{100, 300}),
+		},
+		{
+			desc:     "Split extension range",
+			original: newTestMessages([2]int32{100, 200}),
+			updated:  newTestMessages([2]int32{100, 150}, [2]int32{150, 200}),
+		},
+		{
+			desc:     "Shrunk extension range",
+			original: newTestMessages([2]int32{100, 200}),
+			updated:  newTestMessages([2]int32{100, 150}),
+			errs: []string{
+				`test.proto:1:1:wire:Message "Foo" extension numbers 150 to 199 were removed from the extension ranges.`,
+			},
+		},
+		{
+			desc:     "Removed extension ranges",
+			original: newTestMessages([2]int32{100, 101}, [2]int32{200, 300}),
+			updated:  newTestMessages(),
+			errs: []string{
+				`test.proto:1:1:wire:Message "Foo" extension number 100 was removed from the extension ranges.`,
+				`test.proto:1:1:wire:Message "Foo" extension numbers 200 to 299 were removed from the extension ranges.`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := newTestChecker(t)
+			c.checkMessages(tt.original, tt.updated)
+			require.Len(t, c.errors, len(tt.errs))
+			for i, err := range tt.errs {
+				assert.Equal(t, err, c.errors[i].String())
+			}
+		})
+	}
+}
+
+func TestNewExtensions(t *testing.T) {
+	fd := &descriptor.FieldDescriptorProto{
+		Name:     proto.String("foo"),
+		Number:   proto.Int32(100),
+		Extendee: proto.String(".bar.Baz"),
+	}
+	es := getExtensions([]*descriptor.FieldDescriptorProto{fd}, nil, location.FileExtension, "")
+	require.Contains(t, es, "foo")
+	assert.Equal(t, "bar.Baz", es["foo"].extendee)
+	assert.Equal(t, `Extension "foo" (100)`, es["foo"].Type())
+}
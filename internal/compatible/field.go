@@ -32,6 +32,7 @@ const (
 	_labelPrefix   = "LABEL_"
 	_typePrefix    = "TYPE_"
 	_repeatedLabel = "repeated"
+	_requiredLabel = "required"
 	_singularLabel = "singular"
 	_none          = "none"
 )
@@ -50,45 +51,61 @@ func (fs fields) Items() map[string]descriptorProto {
 
 // field represents a *descriptor.FieldDescriptorProto.
 type field struct {
-	path     location.Path
-	typeID   location.ID
-	name     string
-	typeName string
-	jsonName string
-	label    string
-	oneof    string
-	number   int32
+	path         location.Path
+	typeID       location.ID
+	name         string
+	typeName     string
+	jsonName     string
+	label        string
+	oneof        string
+	number       int32
+	defaultValue string
+	// extendee is only set for extensions.
+	extendee string
+	options  []option
 }
 
 var _ descriptorProto = (*field)(nil)
 
 func (f *field) Name() string        { return f.name }
 func (f *field) Path() location.Path { return f.path }
-func (f *field) Type() string        { return fmt.Sprintf("Field %q (%d)", f.name, f.number) }
+func (f *field) Type() string {
+	if f.extendee != "" {
+		return fmt.Sprintf("Extension %q (%d)", f.name, f.number)
+	}
+	return fmt.Sprintf("Field %q (%d)", f.name, f.number)
+}
+func (f *field) Number() int32 { return f.number }
 
-func newField(fd *descriptor.FieldDescriptorProto, os []*descriptor.OneofDescriptorProto, p location.Path) *field {
+func newField(fd *descriptor.FieldDescriptorProto, os []*descriptor.OneofDescriptorProto, p location.Path, syntax string) *field {
 	typeName, id := getFieldType(fd)
 	return &field{
-		path:     p,
-		name:     fd.GetName(),
-		jsonName: fd.GetJsonName(),
-		typeName: typeName,
-		typeID:   id,
-		label:    getFieldLabel(fd),
-		number:   fd.GetNumber(),
-		oneof:    getOneof(fd, os),
+		path:         p,
+		name:         fd.GetName(),
+		jsonName:     fd.GetJsonName(),
+		typeName:     typeName,
+		typeID:       id,
+		label:        getFieldLabel(fd),
+		number:       fd.GetNumber(),
+		oneof:        getOneof(fd, os),
+		defaultValue: fd.GetDefaultValue(),
+		extendee:     strings.TrimPrefix(fd.GetExtendee(), "."),
+		options:      getFieldOptions(fd, syntax),
 	}
 }
 
 // checkFields verifies that,
-//  - None of the field values/numbers were removed.
+//  - None of the field values/numbers were removed, unless
+//    both the number and the name are reserved.
 //  - None of the field names were updated.
 //  - None of the field json names were updated.
 //  - None of the field types were updated.
 //  - None of the field labels were updated.
 //  - None of the field oneof declarations were updated.
-func (c *fileChecker) checkFields(original, updated fields) {
-	c.checkRemovedItems(original, updated, location.FieldNumber)
+//  - None of the field default values were updated.
+//  - None of the field options were inappropriately updated.
+func (c *fileChecker) checkFields(original, updated fields, updatedReserved *reserved) {
+	c.checkRemovedNumberedItems(original, updated, updatedReserved, location.FieldNumber)
 	for i, uf := range updated {
 		if of, ok := original[i]; ok {
 			c.checkField(of, uf)
@@ -96,6 +113,28 @@ func (c *fileChecker) checkFields(original, updated fields) {
 	}
 }
 
+// checkAddedRequiredFields verifies that none of the fields added
+// to the message are required, as messages written without the
+// field can no longer be parsed.
+//
+// The error is reported at the name of the original message, as
+// the added field does not exist in the original file.
+func (c *fileChecker) checkAddedRequiredFields(original, updated *message) {
+	for i, uf := range updated.fields {
+		if _, ok := original.fields[i]; ok || uf.label != _requiredLabel {
+			continue
+		}
+		c.AddErrorf(
+			original.Path().Target(location.Name),
+			Wire,
+			"%s had the required field %q (%d) added.",
+			original.Type(),
+			uf.name,
+			uf.number,
+		)
+	}
+}
+
 func (c *fileChecker) checkField(original, updated *field) {
 	c.checkUpdatedAttribute(
 		original,
@@ -121,6 +160,20 @@ func (c *fileChecker) checkField(original, updated *field) {
 		updated.oneof,
 		location.Name,
 	)
+	if original.defaultValue != updated.defaultValue {
+		// Default values are not sent over the wire, so
+		// readers and writers will disagree on the value
+		// of any unset field.
+		c.AddErrorf(
+			c.findTarget(original, location.Name, location.FieldDefaultValue),
+			Wire,
+			"%s had its default value updated from %q to %q.",
+			original.Type(),
+			original.defaultValue,
+			updated.defaultValue,
+		)
+	}
+	c.checkOptions(original, location.FieldOption, location.Name, original.options, updated.options)
 	// The label can be safely evolved from "singular" to
 	// "repeated" with respect to wire-compatibility.
 	//
@@ -128,12 +181,18 @@ func (c *fileChecker) checkField(original, updated *field) {
 	// doesn't actually exist, so we set our target
 	// to the field's name.
 	severity, target := Source, location.Name
-	if original.label == _repeatedLabel {
-		// If the original label was "repeated", then
-		// any update is wire-incompatible, i.e.
-		// an update from "repeated" to "singular".
+	if original.label == _repeatedLabel || original.label == _requiredLabel {
+		// If the original label was "repeated" or "required",
+		// then any update is wire-incompatible, i.e. an
+		// update from "repeated" to "singular".
 		severity, target = Wire, location.FieldLabel
 	}
+	if updated.label == _requiredLabel {
+		// Adding "required" is wire-incompatible, as
+		// messages written without the field can no
+		// longer be parsed.
+		severity = Wire
+	}
 	c.checkUpdatedAttribute(
 		original,
 		severity,
@@ -164,9 +223,10 @@ func getFieldType(fd *descriptor.FieldDescriptorProto) (string, location.ID) {
 // the mapping of the default label values to "singular".
 //
 // In proto3, the only valid label is "repeated"; "optional"
-// and "required" are no longer valid.
+// and "required" are no longer valid. In proto2, "optional"
+// is also mapped to "singular".
 func getFieldLabel(fd *descriptor.FieldDescriptorProto) string {
-	if l := lowerTrimPrefix(fd.GetLabel().String(), _labelPrefix); l == _repeatedLabel {
+	if l := lowerTrimPrefix(fd.GetLabel().String(), _labelPrefix); l == _repeatedLabel || l == _requiredLabel {
 		return l
 	}
 	return _singularLabel
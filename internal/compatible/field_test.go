@@ -82,12 +82,36 @@ func TestField(t *testing.T) {
 			updated:  fields{"1": &field{number: 1, name: "old", label: "singular"}},
 			err:      `test.proto:1:1:wire:Field "old" (1) had its label updated from "repeated" to "singular".`,
 		},
+		{
+			desc:     `Updated label from "singular" to "required" (wire-incompatible)`,
+			original: fields{"1": &field{number: 1, name: "old", label: "singular"}},
+			updated:  fields{"1": &field{number: 1, name: "old", label: "required"}},
+			err:      `test.proto:1:1:wire:Field "old" (1) had its label updated from "singular" to "required".`,
+		},
+		{
+			desc:     `Updated label from "required" to "singular" (wire-incompatible)`,
+			original: fields{"1": &field{number: 1, name: "old", label: "required"}},
+			updated:  fields{"1": &field{number: 1, name: "old", label: "singular"}},
+			err:      `test.proto:1:1:wire:Field "old" (1) had its label updated from "required" to "singular".`,
+		},
+		{
+			desc:     "Updated default value",
+			original: fields{"1": &field{number: 1, name: "old", defaultValue: "1"}},
+			updated:  fields{"1": &field{number: 1, name: "old", defaultValue: "2"}},
+			err:      `test.proto:1:1:wire:Field "old" (1) had its default value updated from "1" to "2".`,
+		},
+		{
+			desc:     "Removed default value",
+			original: fields{"1": &field{number: 1, name: "old", defaultValue: "foo"}},
+			updated:  fields{"1": &field{number: 1, name: "old"}},
+			err:      `test.proto:1:1:wire:Field "old" (1) had its default value updated from "foo" to "".`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
 			c := newTestChecker(t)
 			fn := func(o, u descriptorProtoGroup) {
-				c.checkFields(o.(fields), u.(fields))
+				c.checkFields(o.(fields), u.(fields), nil /* reserved */)
 			}
 			check(t, c, fn, tt.original, tt.updated, tt.err)
 		})
@@ -104,7 +128,7 @@ func TestNewField(t *testing.T) {
 			JsonName: proto.String("json"),
 			Number:   proto.Int32(1),
 		}
-		f := newField(fd, nil /* Oneofs */, nil /* location.Path */)
+		f := newField(fd, nil /* Oneofs */, nil /* location.Path */, "" /* syntax */)
 		assert.Equal(t, "name", f.name)
 		assert.Equal(t, "foo.Bar", f.typeName)
 		assert.Equal(t, "json", f.jsonName)
@@ -118,7 +142,7 @@ func TestNewField(t *testing.T) {
 			Type:   &typ,
 			Number: proto.Int32(1),
 		}
-		f := newField(fd, nil /* Oneofs */, nil /* location.Path */)
+		f := newField(fd, nil /* Oneofs */, nil /* location.Path */, "" /* syntax */)
 		assert.Equal(t, fd.GetName(), f.name)
 		assert.Equal(t, "message", f.typeName)
 		assert.Equal(t, "singular", f.label)
@@ -129,10 +153,21 @@ func TestNewField(t *testing.T) {
 			Name:  proto.String("name"),
 			Label: &label,
 		}
-		f := newField(fd, nil /* Oneofs */, nil /* location.Path */)
+		f := newField(fd, nil /* Oneofs */, nil /* location.Path */, "" /* syntax */)
 		assert.Equal(t, fd.GetName(), f.name)
 		assert.Equal(t, "repeated", f.label)
 	})
+	t.Run("Required field with default value", func(t *testing.T) {
+		label := descriptor.FieldDescriptorProto_LABEL_REQUIRED
+		fd := &descriptor.FieldDescriptorProto{
+			Name:         proto.String("name"),
+			Label:        &label,
+			DefaultValue: proto.String("foo"),
+		}
+		f := newField(fd, nil /* Oneofs */, nil /* location.Path */, "" /* syntax */)
+		assert.Equal(t, "required", f.label)
+		assert.Equal(t, "foo", f.defaultValue)
+	})
 	t.Run("Oneof declaration", func(t *testing.T) {
 		oneofs := []*descriptor.OneofDescriptorProto{
 			{
@@ -143,7 +178,7 @@ func TestNewField(t *testing.T) {
 			Name:       proto.String("name"),
 			OneofIndex: proto.Int32(0),
 		}
-		f := newField(fd, oneofs, nil /* location.Path */)
+		f := newField(fd, oneofs, nil /* location.Path */, "" /* syntax */)
 		assert.Equal(t, fd.GetName(), f.name)
 		assert.Equal(t, "oneof", f.oneof)
 	})
@@ -47,6 +47,8 @@ type file struct {
 	enums      enums
 	messages   messages
 	services   services
+	extensions extensions
+	options    []option
 }
 
 func (f *file) Name() string        { return f.name }
@@ -56,7 +58,7 @@ func (f *file) Type() string        { return fmt.Sprintf("File %q", f.name) }
 func newFile(fd *descriptor.FileDescriptorProto, p location.Path) *file {
 	messages := make(messages, len(fd.GetMessageType()))
 	for i, m := range fd.GetMessageType() {
-		messages[m.GetName()] = newMessage(m, p.Scope(location.Message, i))
+		messages[m.GetName()] = newMessage(m, p.Scope(location.Message, i), fd.GetSyntax())
 	}
 	services := make(services, len(fd.GetService()))
 	for i, s := range fd.GetService() {
@@ -71,14 +73,18 @@ func newFile(fd *descriptor.FileDescriptorProto, p location.Path) *file {
 		messages:   messages,
 		enums:      getEnums(fd, p, location.Enum),
 		services:   services,
+		extensions: getExtensions(fd.GetExtension(), p, location.FileExtension, fd.GetSyntax()),
+		options:    getFileOptions(fd.GetOptions()),
 	}
 }
 
 // checkFile verifies that,
 //  - The file's package was not updated.
+//  - None of the file's options were inappropriately updated.
 //  - None of the file's messages were inappropriately updated.
 //  - None of the file's enums were inappropriately updated.
 //  - None of the file's services were inappropriately updated.
+//  - None of the file's extensions were inappropriately updated.
 func (c *fileChecker) checkFile(original, updated *file) []Error {
 	c.checkUpdatedAttribute(
 		original,
@@ -88,8 +94,10 @@ func (c *fileChecker) checkFile(original, updated *file) []Error {
 		updated.pkg,
 		location.Package,
 	)
+	c.checkOptions(original, location.FileOption, location.Package, original.options, updated.options)
 	c.checkMessages(original.messages, updated.messages)
 	c.checkEnums(original.enums, updated.enums)
 	c.checkServices(original.services, updated.services)
+	c.checkExtensions(original.extensions, updated.extensions)
 	return c.errors
 }
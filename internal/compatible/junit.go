@@ -0,0 +1,103 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// PrintJUnitXML prints the Errors as a JUnit XML report, so that
+// breaking changes can be shown by CI systems that understand
+// test results.
+//
+// Each file with Errors is a test suite, and each Error is a
+// test case. Errors with at least the given fail Severity are
+// failed test cases, where the type of each failure is the Severity
+// of the Error. All other Errors are passing test cases with the
+// Error in the system output, so that the failure counts match the
+// exit code of the break check. The Errors are expected to be sorted.
+func PrintJUnitXML(writer io.Writer, errs []Error, failSeverity Severity) error {
+	testSuites := junitTestSuites{
+		Tests: len(errs),
+	}
+	for _, err := range errs {
+		if len(testSuites.TestSuites) == 0 || testSuites.TestSuites[len(testSuites.TestSuites)-1].Name != err.Filename {
+			testSuites.TestSuites = append(testSuites.TestSuites, junitTestSuite{Name: err.Filename})
+		}
+		testSuite := &testSuites.TestSuites[len(testSuites.TestSuites)-1]
+		testSuite.Tests++
+		testCase := junitTestCase{
+			Name:      err.Message,
+			ClassName: err.Filename,
+		}
+		if err.Severity.IsAtLeast(failSeverity) {
+			testSuites.Failures++
+			testSuite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: err.Message,
+				Type:    string(err.Severity),
+				Text:    err.String(),
+			}
+		} else {
+			testCase.SystemOut = err.String()
+		}
+		testSuite.TestCases = append(testSuite.TestCases, testCase)
+	}
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(testSuites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintJUnitXML(t *testing.T) {
+	t.Run("No errors", func(t *testing.T) {
+		buffer := bytes.NewBuffer(nil)
+		require.NoError(t, PrintJUnitXML(buffer, nil, Source))
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="0" failures="0"></testsuites>
+`, buffer.String())
+	})
+	t.Run("Errors", func(t *testing.T) {
+		errs := []Error{
+			{Filename: "bar.proto", Line: 1, Column: 1, Severity: Warn, Message: `Failed to validate file "bar.proto": the file no longer exists.`},
+			{Filename: "foo.proto", Line: 3, Column: 5, Severity: Wire, Message: `Field "foo" (1) was removed.`},
+			{Filename: "foo.proto", Line: 7, Column: 1, Severity: Source, Message: `Message "Foo" had its name updated from "Foo" to "Bar".`},
+		}
+		buffer := bytes.NewBuffer(nil)
+		require.NoError(t, PrintJUnitXML(buffer, errs, Warn))
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="3">
+  <testsuite name="bar.proto" tests="1" failures="1">
+    <testcase name="Failed to validate file &#34;bar.proto&#34;: the file no longer exists." classname="bar.proto">
+      <failure message="Failed to validate file &#34;bar.proto&#34;: the file no longer exists." type="warn">bar.proto:1:1:warn:Failed to validate file &#34;bar.proto&#34;: the file no longer exists.</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="foo.proto" tests="2" failures="2">
+    <testcase name="Field &#34;foo&#34; (1) was removed." classname="foo.proto">
+      <failure message="Field &#34;foo&#34; (1) was removed." type="wire">foo.proto:3:5:wire:Field &#34;foo&#34; (1) was removed.</failure>
+    </testcase>
+    <testcase name="Message &#34;Foo&#34; had its name updated from &#34;Foo&#34; to &#34;Bar&#34;." classname="foo.proto">
+      <failure message="Message &#34;Foo&#34; had its name updated from &#34;Foo&#34; to &#34;Bar&#34;." type="source">foo.proto:7:1:source:Message &#34;Foo&#34; had its name updated from &#34;Foo&#34; to &#34;Bar&#34;.</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buffer.String())
+	})
+	t.Run("Errors below the fail severity", func(t *testing.T) {
+		errs := []Error{
+			{Filename: "bar.proto", Line: 1, Column: 1, Severity: Warn, Message: `Failed to validate file "bar.proto": the file no longer exists.`},
+			{Filename: "foo.proto", Line: 3, Column: 5, Severity: Wire, Message: `Field "foo" (1) was removed.`},
+			{Filename: "foo.proto", Line: 7, Column: 1, Severity: Source, Message: `Message "Foo" had its name updated from "Foo" to "Bar".`},
+		}
+		buffer := bytes.NewBuffer(nil)
+		require.NoError(t, PrintJUnitXML(buffer, errs, Wire))
+		assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1">
+  <testsuite name="bar.proto" tests="1" failures="0">
+    <testcase name="Failed to validate file &#34;bar.proto&#34;: the file no longer exists." classname="bar.proto">
+      <system-out>bar.proto:1:1:warn:Failed to validate file &#34;bar.proto&#34;: the file no longer exists.</system-out>
+    </testcase>
+  </testsuite>
+  <testsuite name="foo.proto" tests="2" failures="1">
+    <testcase name="Field &#34;foo&#34; (1) was removed." classname="foo.proto">
+      <failure message="Field &#34;foo&#34; (1) was removed." type="wire">foo.proto:3:5:wire:Field &#34;foo&#34; (1) was removed.</failure>
+    </testcase>
+    <testcase name="Message &#34;Foo&#34; had its name updated from &#34;Foo&#34; to &#34;Bar&#34;." classname="foo.proto">
+      <system-out>foo.proto:7:1:source:Message &#34;Foo&#34; had its name updated from &#34;Foo&#34; to &#34;Bar&#34;.</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buffer.String())
+	})
+}
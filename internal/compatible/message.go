@@ -42,12 +42,16 @@ func (ms messages) Items() map[string]descriptorProto {
 
 // message represents a *descriptor.DescriptorProto.
 type message struct {
-	path     location.Path
-	name     string
-	fields   fields
-	enums    enums
-	messages messages
-	oneofs   oneofs
+	path            location.Path
+	name            string
+	fields          fields
+	enums           enums
+	messages        messages
+	oneofs          oneofs
+	extensions      extensions
+	extensionRanges []numberRange
+	reserved        *reserved
+	options         []option
 }
 
 var _ descriptorProto = (*message)(nil)
@@ -56,35 +60,50 @@ func (m *message) Name() string        { return m.name }
 func (m *message) Path() location.Path { return m.path }
 func (m *message) Type() string        { return fmt.Sprintf("Message %q", m.name) }
 
-func newMessage(md *descriptor.DescriptorProto, p location.Path) *message {
+func newMessage(md *descriptor.DescriptorProto, p location.Path, syntax string) *message {
 	oneofs := make(oneofs, len(md.GetOneofDecl()))
 	for i, o := range md.GetOneofDecl() {
 		oneofs[o.GetName()] = newOneof(o, p.Scope(location.Oneof, i))
 	}
 	fields := make(fields, len(md.GetField()))
 	for i, f := range md.GetField() {
-		fields[strconv.Itoa(int(f.GetNumber()))] = newField(f, md.GetOneofDecl(), p.Scope(location.Field, i))
+		fields[strconv.Itoa(int(f.GetNumber()))] = newField(f, md.GetOneofDecl(), p.Scope(location.Field, i), syntax)
 	}
 	messages := make(messages, len(md.GetNestedType()))
 	for i, m := range md.GetNestedType() {
-		messages[m.GetName()] = newMessage(m, p.Scope(location.NestedType, i))
+		messages[m.GetName()] = newMessage(m, p.Scope(location.NestedType, i), syntax)
+	}
+	// Note that the end of an extension range is exclusive.
+	extensionRanges := make([]numberRange, len(md.GetExtensionRange()))
+	for i, er := range md.GetExtensionRange() {
+		extensionRanges[i] = numberRange{start: int64(er.GetStart()), end: int64(er.GetEnd()) - 1}
 	}
 	return &message{
-		path:     p,
-		name:     md.GetName(),
-		fields:   fields,
-		enums:    getEnums(md, p, location.MessageEnum),
-		messages: messages,
-		oneofs:   oneofs,
+		path:            p,
+		name:            md.GetName(),
+		fields:          fields,
+		enums:           getEnums(md, p, location.MessageEnum),
+		messages:        messages,
+		oneofs:          oneofs,
+		extensions:      getExtensions(md.GetExtension(), p, location.MessageExtension, syntax),
+		extensionRanges: extensionRanges,
+		reserved:        newMessageReserved(md, p),
+		options:         getMessageOptions(md.GetOptions()),
 	}
 }
 
 // checkMessages verifies that,
 //  - None of the messages were removed.
 //  - None of the messages' fields were inappropriately updated.
+//  - None of the messages had required fields added.
 //  - None of the messages' enums were inappropriately updated.
 //  - None of the messages' nested messages were inappropriately updated.
 //  - None of the messages' oneofs were inappropriately updated.
+//  - None of the messages' extensions were inappropriately updated.
+//  - None of the messages' extension ranges were shrunk.
+//  - None of the messages' options were inappropriately updated.
+//  - None of the messages' reserved numbers were un-reserved.
+//  - None of the messages' reserved names were reused.
 func (c *fileChecker) checkMessages(original, updated messages) {
 	c.checkRemovedItems(original, updated, location.Name)
 	for i, um := range updated {
@@ -95,8 +114,13 @@ func (c *fileChecker) checkMessages(original, updated messages) {
 }
 
 func (c *fileChecker) checkMessage(original, updated *message) {
-	c.checkFields(original.fields, updated.fields)
+	c.checkOptions(original, location.MessageOption, location.Name, original.options, updated.options)
+	c.checkFields(original.fields, updated.fields, updated.reserved)
+	c.checkAddedRequiredFields(original, updated)
+	c.checkReserved(original.reserved, updated.reserved, updated.fields)
 	c.checkEnums(original.enums, updated.enums)
 	c.checkMessages(original.messages, updated.messages)
 	c.checkOneofs(original.oneofs, updated.oneofs)
+	c.checkExtensions(original.extensions, updated.extensions)
+	c.checkExtensionRanges(original, updated)
 }
@@ -47,6 +47,17 @@ func TestMessage(t *testing.T) {
 			updated:  messages{"bar": &message{name: "bar"}},
 			err:      `test.proto:1:1:wire:Message "foo" was removed.`,
 		},
+		{
+			desc:     "Added optional field",
+			original: messages{"foo": &message{name: "foo"}},
+			updated:  messages{"foo": &message{name: "foo", fields: fields{"1": &field{number: 1, name: "bar", label: "singular"}}}},
+		},
+		{
+			desc:     "Added required field",
+			original: messages{"foo": &message{name: "foo"}},
+			updated:  messages{"foo": &message{name: "foo", fields: fields{"1": &field{number: 1, name: "bar", label: "required"}}}},
+			err:      `test.proto:1:1:wire:Message "foo" had the required field "bar" (1) added.`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -61,7 +72,7 @@ func TestMessage(t *testing.T) {
 
 func TestNewMessage(t *testing.T) {
 	t.Run("Empty message", func(t *testing.T) {
-		m := newMessage(&descriptor.DescriptorProto{Name: proto.String("msg")}, nil /* location.Path */)
+		m := newMessage(&descriptor.DescriptorProto{Name: proto.String("msg")}, nil /* location.Path */, "" /* syntax */)
 		assert.Equal(t, "msg", m.name)
 	})
 	t.Run("Non-empty message", func(t *testing.T) {
@@ -83,7 +94,7 @@ func TestNewMessage(t *testing.T) {
 					Name: proto.String("oneof"),
 				},
 			},
-		}, nil /* location.Path */)
+		}, nil /* location.Path */, "" /* syntax */)
 
 		require.Len(t, m.fields, 1)
 		require.Len(t, m.messages, 1)
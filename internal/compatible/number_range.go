@@ -0,0 +1,63 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import "sort"
+
+// numberRange is a range of field or enum value numbers.
+//
+// Both start and end are inclusive. These are int64 values so
+// that the end of a range can be incremented without overflow.
+type numberRange struct {
+	start int64
+	end   int64
+}
+
+// contains returns true if the number is within the range.
+func (r numberRange) contains(number int32) bool {
+	return r.start <= int64(number) && int64(number) <= r.end
+}
+
+// subtract returns the parts of the range that are not
+// within any of the given ranges.
+func (r numberRange) subtract(ranges []numberRange) []numberRange {
+	sorted := make([]numberRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i int, j int) bool { return sorted[i].start < sorted[j].start })
+	var remaining []numberRange
+	cur := r.start
+	for _, o := range sorted {
+		if cur > r.end || o.start > r.end {
+			break
+		}
+		if o.end < cur {
+			continue
+		}
+		if o.start > cur {
+			remaining = append(remaining, numberRange{start: cur, end: o.start - 1})
+		}
+		cur = o.end + 1
+	}
+	if cur <= r.end {
+		remaining = append(remaining, numberRange{start: cur, end: r.end})
+	}
+	return remaining
+}
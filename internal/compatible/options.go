@@ -0,0 +1,140 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"strconv"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/location"
+)
+
+// option represents the value of a single option on a descriptor.
+//
+// The id is the field number of the option within its options
+// message, such as 11 for go_package within FileOptions.
+type option struct {
+	id       location.ID
+	name     string
+	severity Severity
+	value    string
+}
+
+// The following functions return the options of each type of options
+// message, in a consistent order so that they can be compared.
+//
+// Options that only affect generated code are a source break,
+// options that affect how messages are serialized or parsed are
+// a wire break, and options that neither break generated code nor
+// parsers, such as deprecated, only result in a warning.
+
+func getFileOptions(o *descriptor.FileOptions) []option {
+	return []option{
+		{location.JavaPackage, "java_package", Source, o.GetJavaPackage()},
+		{location.JavaOuterClassname, "java_outer_classname", Source, o.GetJavaOuterClassname()},
+		{location.OptimizeFor, "optimize_for", Source, o.GetOptimizeFor().String()},
+		{location.JavaMultipleFiles, "java_multiple_files", Source, strconv.FormatBool(o.GetJavaMultipleFiles())},
+		{location.GoPackage, "go_package", Source, o.GetGoPackage()},
+		{location.CcGenericServices, "cc_generic_services", Source, strconv.FormatBool(o.GetCcGenericServices())},
+		{location.JavaGenericServices, "java_generic_services", Source, strconv.FormatBool(o.GetJavaGenericServices())},
+		{location.PyGenericServices, "py_generic_services", Source, strconv.FormatBool(o.GetPyGenericServices())},
+		{location.FileDeprecated, "deprecated", Warn, strconv.FormatBool(o.GetDeprecated())},
+		{location.JavaStringCheckUtf8, "java_string_check_utf8", Wire, strconv.FormatBool(o.GetJavaStringCheckUtf8())},
+		{location.CcEnableArenas, "cc_enable_arenas", Source, strconv.FormatBool(o.GetCcEnableArenas())},
+		{location.ObjcClassPrefix, "objc_class_prefix", Source, o.GetObjcClassPrefix()},
+		{location.CsharpNamespace, "csharp_namespace", Source, o.GetCsharpNamespace()},
+		{location.SwiftPrefix, "swift_prefix", Source, o.GetSwiftPrefix()},
+		{location.PhpClassPrefix, "php_class_prefix", Source, o.GetPhpClassPrefix()},
+		{location.PhpNamespace, "php_namespace", Source, o.GetPhpNamespace()},
+	}
+}
+
+func getMessageOptions(o *descriptor.MessageOptions) []option {
+	return []option{
+		{location.MessageSetWireFormat, "message_set_wire_format", Wire, strconv.FormatBool(o.GetMessageSetWireFormat())},
+		{location.NoStandardDescriptorAccessor, "no_standard_descriptor_accessor", Source, strconv.FormatBool(o.GetNoStandardDescriptorAccessor())},
+		{location.Deprecated, "deprecated", Warn, strconv.FormatBool(o.GetDeprecated())},
+	}
+}
+
+func getFieldOptions(fd *descriptor.FieldDescriptorProto, syntax string) []option {
+	o := fd.GetOptions()
+	return []option{
+		{location.FieldCtype, "ctype", Source, o.GetCtype().String()},
+		// parsers must accept both packed and unpacked encodings,
+		// so only parsers that predate packed fields are affected
+		{location.FieldPacked, "packed", Warn, strconv.FormatBool(isPacked(fd, syntax))},
+		{location.Deprecated, "deprecated", Warn, strconv.FormatBool(o.GetDeprecated())},
+		{location.FieldLazy, "lazy", Warn, strconv.FormatBool(o.GetLazy())},
+		{location.FieldJstype, "jstype", Source, o.GetJstype().String()},
+		{location.FieldWeak, "weak", Source, strconv.FormatBool(o.GetWeak())},
+	}
+}
+
+// isPacked returns the effective value of the packed option of the field,
+// which defaults to true for repeated scalar numeric fields in proto3.
+func isPacked(fd *descriptor.FieldDescriptorProto, syntax string) bool {
+	if o := fd.GetOptions(); o != nil && o.Packed != nil {
+		return o.GetPacked()
+	}
+	if syntax != "proto3" || fd.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return false
+	}
+	switch fd.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_STRING,
+		descriptor.FieldDescriptorProto_TYPE_BYTES,
+		descriptor.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptor.FieldDescriptorProto_TYPE_GROUP:
+		return false
+	}
+	return true
+}
+
+func getEnumOptions(o *descriptor.EnumOptions) []option {
+	return []option{
+		{location.AllowAlias, "allow_alias", Source, strconv.FormatBool(o.GetAllowAlias())},
+		{location.Deprecated, "deprecated", Warn, strconv.FormatBool(o.GetDeprecated())},
+	}
+}
+
+// checkOptions verifies that none of the options of the given
+// descriptorProto were updated. The options are expected to be
+// derived from the same option definitions.
+//
+// The error is reported at the location of the original option.
+// If the option was not set on the original descriptorProto,
+// the fallback target on the descriptorProto is used instead.
+func (c *fileChecker) checkOptions(typ descriptorProto, optionsID location.ID, fallback location.ID, original, updated []option) {
+	for i := 0; i < len(original) && i < len(updated); i++ {
+		if original[i].value == updated[i].value {
+			continue
+		}
+		c.AddErrorf(
+			c.findTarget(typ, fallback, optionsID, original[i].id),
+			original[i].severity,
+			"%s had its %s option updated from %q to %q.",
+			typ.Type(),
+			original[i].name,
+			original[i].value,
+			updated[i].value,
+		)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions(t *testing.T) {
+	newFileDescriptorSet := func(fileOptions *descriptor.FileOptions, messageOptions *descriptor.MessageOptions, fieldOptions *descriptor.FieldOptions, enumOptions *descriptor.EnumOptions) *descriptor.FileDescriptorSet {
+		return &descriptor.FileDescriptorSet{
+			File: []*descriptor.FileDescriptorProto{
+				{
+					Name:    proto.String(_testFilename),
+					Options: fileOptions,
+					MessageType: []*descriptor.DescriptorProto{
+						{
+							Name:    proto.String("Foo"),
+							Options: messageOptions,
+							Field: []*descriptor.FieldDescriptorProto{
+								{
+									Name:    proto.String("foo"),
+									Number:  proto.Int32(1),
+									Options: fieldOptions,
+								},
+							},
+						},
+					},
+					EnumType: []*descriptor.EnumDescriptorProto{
+						{
+							Name:    proto.String("Bar"),
+							Options: enumOptions,
+						},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		desc     string
+		original *descriptor.FileDescriptorSet
+		updated  *descriptor.FileDescriptorSet
+		err      string
+	}{
+		{
+			desc:     "No change",
+			original: newFileDescriptorSet(&descriptor.FileOptions{GoPackage: proto.String("foopb")}, nil, nil, nil),
+			updated:  newFileDescriptorSet(&descriptor.FileOptions{GoPackage: proto.String("foopb")}, nil, nil, nil),
+		},
+		{
+			desc:     "Explicitly set to default value",
+			original: newFileDescriptorSet(nil, nil, nil, nil),
+			updated:  newFileDescriptorSet(&descriptor.FileOptions{JavaMultipleFiles: proto.Bool(false)}, nil, nil, nil),
+		},
+		{
+			desc:     "Updated go_package",
+			original: newFileDescriptorSet(&descriptor.FileOptions{GoPackage: proto.String("foopb")}, nil, nil, nil),
+			updated:  newFileDescriptorSet(&descriptor.FileOptions{GoPackage: proto.String("barpb")}, nil, nil, nil),
+			err:      `test.proto:1:1:source:File "test.proto" had its go_package option updated from "foopb" to "barpb".`,
+		},
+		{
+			desc:     "Added java_multiple_files",
+			original: newFileDescriptorSet(nil, nil, nil, nil),
+			updated:  newFileDescriptorSet(&descriptor.FileOptions{JavaMultipleFiles: proto.Bool(true)}, nil, nil, nil),
+			err:      `test.proto:1:1:source:File "test.proto" had its java_multiple_files option updated from "false" to "true".`,
+		},
+		{
+			desc:     "Updated message_set_wire_format",
+			original: newFileDescriptorSet(nil, nil, nil, nil),
+			updated:  newFileDescriptorSet(nil, &descriptor.MessageOptions{MessageSetWireFormat: proto.Bool(true)}, nil, nil),
+			err:      `test.proto:1:1:wire:Message "Foo" had its message_set_wire_format option updated from "false" to "true".`,
+		},
+		{
+			desc:     "Updated packed",
+			original: newFileDescriptorSet(nil, nil, &descriptor.FieldOptions{Packed: proto.Bool(true)}, nil),
+			updated:  newFileDescriptorSet(nil, nil, &descriptor.FieldOptions{Packed: proto.Bool(false)}, nil),
+			err:      `test.proto:1:1:warn:Field "foo" (1) had its packed option updated from "true" to "false".`,
+		},
+		{
+			desc:     "Deprecated field",
+			original: newFileDescriptorSet(nil, nil, nil, nil),
+			updated:  newFileDescriptorSet(nil, nil, &descriptor.FieldOptions{Deprecated: proto.Bool(true)}, nil),
+			err:      `test.proto:1:1:warn:Field "foo" (1) had its deprecated option updated from "false" to "true".`,
+		},
+		{
+			desc:     "Updated allow_alias",
+			original: newFileDescriptorSet(nil, nil, nil, &descriptor.EnumOptions{AllowAlias: proto.Bool(true)}),
+			updated:  newFileDescriptorSet(nil, nil, nil, nil),
+			err:      `test.proto:1:1:source:Enum "Bar" had its allow_alias option updated from "true" to "false".`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := Check(tt.original, tt.updated)
+			if tt.err == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.err, errs[0].String())
+		})
+	}
+}
+
+func TestPackedOption(t *testing.T) {
+	newFileDescriptorSet := func(syntax string, packed *bool) *descriptor.FileDescriptorSet {
+		var options *descriptor.FieldOptions
+		if packed != nil {
+			options = &descriptor.FieldOptions{Packed: packed}
+		}
+		return &descriptor.FileDescriptorSet{
+			File: []*descriptor.FileDescriptorProto{
+				{
+					Name:   proto.String(_testFilename),
+					Syntax: proto.String(syntax),
+					MessageType: []*descriptor.DescriptorProto{
+						{
+							Name: proto.String("Foo"),
+							Field: []*descriptor.FieldDescriptorProto{
+								{
+									Name:    proto.String("foo"),
+									Number:  proto.Int32(1),
+									Label:   descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+									Type:    descriptor.FieldDescriptorProto_TYPE_INT32.Enum(),
+									Options: options,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		desc     string
+		original *descriptor.FileDescriptorSet
+		updated  *descriptor.FileDescriptorSet
+		err      string
+	}{
+		{
+			desc:     "Explicitly packed in proto3",
+			original: newFileDescriptorSet("proto3", nil),
+			updated:  newFileDescriptorSet("proto3", proto.Bool(true)),
+		},
+		{
+			desc:     "Explicitly unpacked in proto3",
+			original: newFileDescriptorSet("proto3", nil),
+			updated:  newFileDescriptorSet("proto3", proto.Bool(false)),
+			err:      `test.proto:1:1:warn:Field "foo" (1) had its packed option updated from "true" to "false".`,
+		},
+		{
+			desc:     "Explicitly unpacked in proto2",
+			original: newFileDescriptorSet("proto2", nil),
+			updated:  newFileDescriptorSet("proto2", proto.Bool(false)),
+		},
+		{
+			desc:     "Packed in proto2",
+			original: newFileDescriptorSet("proto2", nil),
+			updated:  newFileDescriptorSet("proto2", proto.Bool(true)),
+			err:      `test.proto:1:1:warn:Field "foo" (1) had its packed option updated from "false" to "true".`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			errs := Check(tt.original, tt.updated)
+			if tt.err == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.err, errs[0].String())
+		})
+	}
+}
+
+func TestOptionsLocation(t *testing.T) {
+	newFileDescriptorProto := func(goPackage string, sourceCodeInfo *descriptor.SourceCodeInfo) *descriptor.FileDescriptorProto {
+		return &descriptor.FileDescriptorProto{
+			Name:           proto.String(_testFilename),
+			Package:        proto.String("foo"),
+			Options:        &descriptor.FileOptions{GoPackage: proto.String(goPackage)},
+			SourceCodeInfo: sourceCodeInfo,
+		}
+	}
+	original := newFileDescriptorProto(
+		"foopb",
+		&descriptor.SourceCodeInfo{
+			Location: []*descriptor.SourceCodeInfo_Location{
+				{
+					// package foo;
+					Path: []int32{2},
+					Span: []int32{2, 0, 12},
+				},
+				{
+					// option go_package = "foopb";
+					Path: []int32{8, 11},
+					Span: []int32{4, 0, 29},
+				},
+			},
+		},
+	)
+	c := newFileChecker(original)
+	c.checkFile(newFile(original, nil), newFile(newFileDescriptorProto("barpb", nil), nil))
+	require.Len(t, c.errors, 1)
+	assert.Equal(t, `test.proto:5:1:source:File "test.proto" had its go_package option updated from "foopb" to "barpb".`, c.errors[0].String())
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/location"
+)
+
+// pkg represents all of the top-level types of a package, regardless
+// of the files they are defined in.
+type pkg struct {
+	enums      enums
+	messages   messages
+	services   services
+	extensions extensions
+}
+
+// newPackages indexes the top-level types of the given FileDescriptorSet
+// by package. As packages are namespaces, the name of each type is unique
+// within its package, so this is an index by fully-qualified name.
+func newPackages(fds *descriptor.FileDescriptorSet) map[string]*pkg {
+	var basePath location.Path
+	pkgs := make(map[string]*pkg)
+	for _, fd := range fds.GetFile() {
+		p, ok := pkgs[fd.GetPackage()]
+		if !ok {
+			p = &pkg{
+				enums:      make(enums),
+				messages:   make(messages),
+				services:   make(services),
+				extensions: make(extensions),
+			}
+			pkgs[fd.GetPackage()] = p
+		}
+		f := newFile(fd, basePath)
+		for name, e := range f.enums {
+			p.enums[name] = e
+		}
+		for name, m := range f.messages {
+			p.messages[name] = m
+		}
+		for name, s := range f.services {
+			p.services[name] = s
+		}
+		for name, e := range f.extensions {
+			p.extensions[name] = e
+		}
+	}
+	return pkgs
+}
+
+// checkPackageFile verifies that none of the original file's types
+// were removed from its package or inappropriately updated, regardless
+// of the file they are now defined in.
+//
+// If a file with the same name still exists, its package and options
+// are also checked, as for checkFile. The updatedFile is nil otherwise.
+func (c *fileChecker) checkPackageFile(original *file, updatedFile *file, updated *pkg) []Error {
+	if updated == nil {
+		updated = &pkg{}
+	}
+	if updatedFile != nil {
+		c.checkUpdatedAttribute(
+			original,
+			Wire,
+			"package",
+			original.pkg,
+			updatedFile.pkg,
+			location.Package,
+		)
+		c.checkOptions(original, location.FileOption, location.Package, original.options, updatedFile.options)
+	}
+	updatedEnums := make(enums)
+	for name := range original.enums {
+		if e, ok := updated.enums[name]; ok {
+			updatedEnums[name] = e
+		}
+	}
+	updatedMessages := make(messages)
+	for name := range original.messages {
+		if m, ok := updated.messages[name]; ok {
+			updatedMessages[name] = m
+		}
+	}
+	updatedServices := make(services)
+	for name := range original.services {
+		if s, ok := updated.services[name]; ok {
+			updatedServices[name] = s
+		}
+	}
+	updatedExtensions := make(extensions)
+	for name := range original.extensions {
+		if e, ok := updated.extensions[name]; ok {
+			updatedExtensions[name] = e
+		}
+	}
+	c.checkMessages(original.messages, updatedMessages)
+	c.checkEnums(original.enums, updatedEnums)
+	c.checkServices(original.services, updatedServices)
+	c.checkExtensions(original.extensions, updatedExtensions)
+	return c.errors
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWithPackageScope(t *testing.T) {
+	newFileDescriptorProto := func(filename string, pkg string, messageNames ...string) *descriptor.FileDescriptorProto {
+		fd := &descriptor.FileDescriptorProto{
+			Name:    proto.String(filename),
+			Package: proto.String(pkg),
+		}
+		for _, messageName := range messageNames {
+			fd.MessageType = append(fd.MessageType, &descriptor.DescriptorProto{Name: proto.String(messageName)})
+		}
+		return fd
+	}
+	original := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{
+			newFileDescriptorProto("foo.proto", "foo", "Foo", "Bar"),
+		},
+	}
+	t.Run("Moved message", func(t *testing.T) {
+		updated := &descriptor.FileDescriptorSet{
+			File: []*descriptor.FileDescriptorProto{
+				newFileDescriptorProto("foo.proto", "foo", "Foo"),
+				newFileDescriptorProto("foo_types.proto", "foo", "Bar"),
+			},
+		}
+		assert.Empty(t, Check(original, updated, CheckWithPackageScope()))
+		assert.NotEmpty(t, Check(original, updated))
+	})
+	t.Run("Moved file", func(t *testing.T) {
+		updated := &descriptor.FileDescriptorSet{
+			File: []*descriptor.FileDescriptorProto{
+				newFileDescriptorProto("bar.proto", "foo", "Foo", "Bar"),
+			},
+		}
+		assert.Empty(t, Check(original, updated, CheckWithPackageScope()))
+	})
+	t.Run("Removed message", func(t *testing.T) {
+		updated := &descriptor.FileDescriptorSet{
+			File: []*descriptor.FileDescriptorProto{
+				newFileDescriptorProto("foo.proto", "foo", "Foo"),
+				newFileDescriptorProto("foo_types.proto", "foo"),
+			},
+		}
+		errs := Check(original, updated, CheckWithPackageScope())
+		require.Len(t, errs, 1)
+		assert.Equal(t, `foo.proto:1:1:wire:Message "Bar" was removed.`, errs[0].String())
+	})
+	t.Run("Message moved to another package", func(t *testing.T) {
+		updated := &descriptor.FileDescriptorSet{
+			File: []*descriptor.FileDescriptorProto{
+				newFileDescriptorProto("foo.proto", "foo", "Foo"),
+				newFileDescriptorProto("bar.proto", "bar", "Bar"),
+			},
+		}
+		errs := Check(original, updated, CheckWithPackageScope())
+		require.Len(t, errs, 1)
+		assert.Equal(t, `foo.proto:1:1:wire:Message "Bar" was removed.`, errs[0].String())
+	})
+	t.Run("Updated go_package", func(t *testing.T) {
+		original := &descriptor.FileDescriptorSet{
+			File: []*descriptor.FileDescriptorProto{
+				newFileDescriptorProto("foo.proto", "foo", "Foo"),
+			},
+		}
+		original.File[0].Options = &descriptor.FileOptions{GoPackage: proto.String("foopb")}
+		updated := &descriptor.FileDescriptorSet{
+			File: []*descriptor.FileDescriptorProto{
+				newFileDescriptorProto("foo.proto", "foo", "Foo"),
+			},
+		}
+		updated.File[0].Options = &descriptor.FileOptions{GoPackage: proto.String("barpb")}
+		errs := Check(original, updated, CheckWithPackageScope())
+		require.Len(t, errs, 1)
+		assert.Equal(t, `foo.proto:1:1:source:File "foo.proto" had its go_package option updated from "foopb" to "barpb".`, errs[0].String())
+	})
+	t.Run("Removed package", func(t *testing.T) {
+		errs := Check(original, &descriptor.FileDescriptorSet{}, CheckWithPackageScope())
+		assert.Len(t, errs, 2)
+	})
+}
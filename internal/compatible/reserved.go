@@ -0,0 +1,183 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/location"
+)
+
+// numberedDescriptorProto is implemented by the descriptorProto
+// types that have a number, namely fields and enum values.
+type numberedDescriptorProto interface {
+	descriptorProto
+	Number() int32
+}
+
+// reserved represents the reserved numbers and names of
+// a *descriptor.DescriptorProto or *descriptor.EnumDescriptorProto.
+type reserved struct {
+	path    location.Path
+	rangeID location.ID
+	nameID  location.ID
+	ranges  []numberRange
+	// names maps each reserved name to its index.
+	names map[string]int
+}
+
+// newMessageReserved returns the reserved numbers and names of the message.
+// Note that the end of a message reserved range is exclusive.
+func newMessageReserved(md *descriptor.DescriptorProto, p location.Path) *reserved {
+	ranges := make([]numberRange, len(md.GetReservedRange()))
+	for i, rr := range md.GetReservedRange() {
+		ranges[i] = numberRange{start: int64(rr.GetStart()), end: int64(rr.GetEnd()) - 1}
+	}
+	return newReserved(p, location.MessageReservedRange, location.MessageReservedName, ranges, md.GetReservedName())
+}
+
+// newEnumReserved returns the reserved numbers and names of the enum.
+// Note that the end of an enum reserved range is inclusive.
+func newEnumReserved(ed *descriptor.EnumDescriptorProto, p location.Path) *reserved {
+	ranges := make([]numberRange, len(ed.GetReservedRange()))
+	for i, rr := range ed.GetReservedRange() {
+		ranges[i] = numberRange{start: int64(rr.GetStart()), end: int64(rr.GetEnd())}
+	}
+	return newReserved(p, location.EnumReservedRange, location.EnumReservedName, ranges, ed.GetReservedName())
+}
+
+func newReserved(p location.Path, rangeID location.ID, nameID location.ID, ranges []numberRange, names []string) *reserved {
+	nameToIndex := make(map[string]int, len(names))
+	for i, name := range names {
+		nameToIndex[name] = i
+	}
+	return &reserved{
+		path:    p,
+		rangeID: rangeID,
+		nameID:  nameID,
+		ranges:  ranges,
+		names:   nameToIndex,
+	}
+}
+
+// hasNumber returns true if the number is reserved.
+func (r *reserved) hasNumber(number int32) bool {
+	if r == nil {
+		return false
+	}
+	for _, rr := range r.ranges {
+		if rr.contains(number) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasName returns true if the name is reserved.
+func (r *reserved) hasName(name string) bool {
+	if r == nil {
+		return false
+	}
+	_, ok := r.names[name]
+	return ok
+}
+
+// unreserved returns the parts of the given range that
+// are not reserved.
+func (r *reserved) unreserved(rr numberRange) []numberRange {
+	if r == nil {
+		return []numberRange{rr}
+	}
+	return rr.subtract(r.ranges)
+}
+
+// checkRemovedNumberedItems is equivalent to checkRemovedItems for
+// fields and enum values, except that removals are only reported as
+// a warning if both the number and the name of the removed item are
+// reserved, as this is the accepted way to remove these items.
+func (c *fileChecker) checkRemovedNumberedItems(original, updated descriptorProtoGroup, updatedReserved *reserved, id location.ID) {
+	originalItems, updatedItems := original.Items(), updated.Items()
+	for key, u := range updatedItems {
+		if o, ok := originalItems[key]; ok {
+			c.checkRenamedItem(o, u)
+		}
+	}
+	for key, o := range originalItems {
+		if _, ok := updatedItems[key]; ok {
+			continue
+		}
+		c.addRemoved(o.Path().Target(id), o)
+		if n, ok := o.(numberedDescriptorProto); ok && updatedReserved.hasNumber(n.Number()) && updatedReserved.hasName(n.Name()) {
+			c.AddErrorf(
+				o.Path().Target(id),
+				Warn,
+				"%s was removed, and its number and name were reserved.",
+				o.Type(),
+			)
+			continue
+		}
+		c.AddErrorf(
+			o.Path().Target(id),
+			Wire,
+			"%s was removed.",
+			o.Type(),
+		)
+	}
+}
+
+// checkReserved verifies that,
+//  - None of the reserved numbers were un-reserved.
+//  - None of the reserved names were used by the updated items.
+func (c *fileChecker) checkReserved(original, updated *reserved, updatedItems descriptorProtoGroup) {
+	if original == nil {
+		return
+	}
+	for i, rr := range original.ranges {
+		for _, unreserved := range updated.unreserved(rr) {
+			if unreserved.start == unreserved.end {
+				c.AddErrorf(
+					original.path.Scope(original.rangeID, i),
+					Wire,
+					"Reserved number %d was un-reserved.",
+					unreserved.start,
+				)
+				continue
+			}
+			c.AddErrorf(
+				original.path.Scope(original.rangeID, i),
+				Wire,
+				"Reserved numbers %d to %d were un-reserved.",
+				unreserved.start,
+				unreserved.end,
+			)
+		}
+	}
+	for _, u := range updatedItems.Items() {
+		if i, ok := original.names[u.Name()]; ok {
+			c.AddErrorf(
+				original.path.Scope(original.nameID, i),
+				Wire,
+				"%s uses the reserved name %q.",
+				u.Type(),
+				u.Name(),
+			)
+		}
+	}
+}
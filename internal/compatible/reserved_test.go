@@ -0,0 +1,177 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReservedUnreserved(t *testing.T) {
+	tests := []struct {
+		desc     string
+		ranges   []numberRange
+		original numberRange
+		want     []numberRange
+	}{
+		{
+			desc:     "Nothing reserved",
+			original: numberRange{start: 1, end: 5},
+			want:     []numberRange{{start: 1, end: 5}},
+		},
+		{
+			desc:     "Fully reserved",
+			ranges:   []numberRange{{start: 1, end: 10}},
+			original: numberRange{start: 1, end: 5},
+		},
+		{
+			desc:     "Split ranges",
+			ranges:   []numberRange{{start: 4, end: 5}, {start: 1, end: 2}},
+			original: numberRange{start: 1, end: 5},
+			want:     []numberRange{{start: 3, end: 3}},
+		},
+		{
+			desc:     "Partially reserved",
+			ranges:   []numberRange{{start: 2, end: 3}, {start: 10, end: 20}},
+			original: numberRange{start: 1, end: 5},
+			want:     []numberRange{{start: 1, end: 1}, {start: 4, end: 5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r := newReserved(nil, 0, 0, tt.ranges, nil)
+			assert.Equal(t, tt.want, r.unreserved(tt.original))
+		})
+	}
+}
+
+func TestReservedMessage(t *testing.T) {
+	newTestMessages := func(fieldNames map[int32]string, reservedRanges [][2]int32, reservedNames ...string) messages {
+		md := &descriptor.DescriptorProto{
+			Name:         proto.String("Foo"),
+			ReservedName: reservedNames,
+		}
+		for number, name := range fieldNames {
+			md.Field = append(md.Field, &descriptor.FieldDescriptorProto{
+				Name:   proto.String(name),
+				Number: proto.Int32(number),
+			})
+		}
+		for _, reservedRange := range reservedRanges {
+			md.ReservedRange = append(md.ReservedRange, &descriptor.DescriptorProto_ReservedRange{
+				Start: proto.Int32(reservedRange[0]),
+				End:   proto.Int32(reservedRange[1]),
+			})
+		}
+		return messages{"Foo": newMessage(md, nil, "")}
+	}
+	tests := []struct {
+		desc     string
+		original messages
+		updated  messages
+		err      string
+	}{
+		{
+			desc:     "Removed field with reserved number and name",
+			original: newTestMessages(map[int32]string{1: "foo", 2: "bar"}, nil),
+			updated:  newTestMessages(map[int32]string{1: "foo"}, [][2]int32{{2, 3}}, "bar"),
+			err:      `test.proto:1:1:warn:Field "bar" (2) was removed, and its number and name were reserved.`,
+		},
+		{
+			desc:     "Removed field with reserved number only",
+			original: newTestMessages(map[int32]string{1: "foo", 2: "bar"}, nil),
+			updated:  newTestMessages(map[int32]string{1: "foo"}, [][2]int32{{2, 3}}),
+			err:      `test.proto:1:1:wire:Field "bar" (2) was removed.`,
+		},
+		{
+			desc:     "Added reserved range",
+			original: newTestMessages(map[int32]string{1: "foo"}, [][2]int32{{2, 3}}),
+			updated:  newTestMessages(map[int32]string{1: "foo"}, [][2]int32{{2, 3}, {5, 10}}),
+		},
+		{
+			desc:     "Un-reserved number",
+			original: newTestMessages(map[int32]string{1: "foo"}, [][2]int32{{2, 3}}),
+			updated:  newTestMessages(map[int32]string{1: "foo"}, nil),
+			err:      `test.proto:1:1:wire:Reserved number 2 was un-reserved.`,
+		},
+		{
+			desc:     "Un-reserved numbers",
+			original: newTestMessages(map[int32]string{1: "foo"}, [][2]int32{{2, 11}}),
+			updated:  newTestMessages(map[int32]string{1: "foo"}, [][2]int32{{2, 6}}),
+			err:      `test.proto:1:1:wire:Reserved numbers 6 to 10 were un-reserved.`,
+		},
+		{
+			desc:     "Reused reserved name",
+			original: newTestMessages(map[int32]string{1: "foo"}, nil, "bar"),
+			updated:  newTestMessages(map[int32]string{1: "foo", 2: "bar"}, nil, "bar"),
+			err:      `test.proto:1:1:wire:Field "bar" (2) uses the reserved name "bar".`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			c := newTestChecker(t)
+			fn := func(o, u descriptorProtoGroup) {
+				c.checkMessages(o.(messages), u.(messages))
+			}
+			check(t, c, fn, tt.original, tt.updated, tt.err)
+		})
+	}
+}
+
+func TestReservedEnum(t *testing.T) {
+	newTestEnums := func(values map[int32]string, reservedRanges [][2]int32, reservedNames ...string) enums {
+		ed := &descriptor.EnumDescriptorProto{
+			Name:         proto.String("Foo"),
+			Value:        newTestEnumValues(t, values),
+			ReservedName: reservedNames,
+		}
+		for _, reservedRange := range reservedRanges {
+			ed.ReservedRange = append(ed.ReservedRange, &descriptor.EnumDescriptorProto_EnumReservedRange{
+				Start: proto.Int32(reservedRange[0]),
+				End:   proto.Int32(reservedRange[1]),
+			})
+		}
+		return enums{"Foo": newEnum(ed, nil)}
+	}
+	t.Run("Removed value with reserved number and name", func(t *testing.T) {
+		c := newTestChecker(t)
+		c.checkEnums(
+			newTestEnums(map[int32]string{0: "FOO_INVALID", 1: "FOO_BAR"}, nil),
+			newTestEnums(map[int32]string{0: "FOO_INVALID"}, [][2]int32{{1, 1}}, "FOO_BAR"),
+		)
+		require.Len(t, c.errors, 1)
+		assert.Equal(t, `test.proto:1:1:warn:Enum value "FOO_BAR" (1) was removed, and its number and name were reserved.`, c.errors[0].String())
+	})
+	t.Run("Un-reserved number", func(t *testing.T) {
+		// enum reserved ranges are inclusive
+		c := newTestChecker(t)
+		c.checkEnums(
+			newTestEnums(map[int32]string{0: "FOO_INVALID"}, [][2]int32{{1, 2}}),
+			newTestEnums(map[int32]string{0: "FOO_INVALID"}, [][2]int32{{1, 1}}),
+		)
+		require.Len(t, c.errors, 1)
+		assert.Equal(t, `test.proto:1:1:wire:Reserved number 2 was un-reserved.`, c.errors[0].String())
+	})
+}
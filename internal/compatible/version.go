@@ -0,0 +1,53 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+// VersionBump represents a semantic version bump.
+type VersionBump string
+
+// The different version bumps, as defined by https://semver.org.
+const (
+	VersionBumpNone  VersionBump = "none"
+	VersionBumpPatch VersionBump = "patch"
+	VersionBumpMinor VersionBump = "minor"
+	VersionBumpMajor VersionBump = "major"
+)
+
+// SuggestVersionBump returns the semantic version bump for the given
+// Errors and Additions. The bump is,
+//  - major if any of the Errors is at least the given Severity.
+//  - minor if anything was added.
+//  - patch if there are any other Errors, such as warnings.
+//  - none otherwise.
+func SuggestVersionBump(errs []Error, additions []Addition, failSeverity Severity) VersionBump {
+	for _, err := range errs {
+		if err.Severity.IsAtLeast(failSeverity) {
+			return VersionBumpMajor
+		}
+	}
+	if len(additions) > 0 {
+		return VersionBumpMinor
+	}
+	if len(errs) > 0 {
+		return VersionBumpPatch
+	}
+	return VersionBumpNone
+}
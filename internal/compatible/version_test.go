@@ -0,0 +1,74 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compatible
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestVersionBump(t *testing.T) {
+	tests := []struct {
+		desc         string
+		errs         []Error
+		additions    []Addition
+		failSeverity Severity
+		want         VersionBump
+	}{
+		{
+			desc:         "No changes",
+			failSeverity: Source,
+			want:         VersionBumpNone,
+		},
+		{
+			desc:         "Warnings",
+			errs:         []Error{{Severity: Warn}},
+			failSeverity: Source,
+			want:         VersionBumpPatch,
+		},
+		{
+			desc:         "Additions",
+			errs:         []Error{{Severity: Warn}},
+			additions:    []Addition{{}},
+			failSeverity: Source,
+			want:         VersionBumpMinor,
+		},
+		{
+			desc:         "Breaking changes",
+			errs:         []Error{{Severity: Source}},
+			additions:    []Addition{{}},
+			failSeverity: Source,
+			want:         VersionBumpMajor,
+		},
+		{
+			desc:         "Breaking changes below the fail severity",
+			errs:         []Error{{Severity: Source}},
+			failSeverity: Wire,
+			want:         VersionBumpPatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.want, SuggestVersionBump(tt.errs, tt.additions, tt.failSeverity))
+		})
+	}
+}
@@ -54,3 +54,24 @@ func SortFileDescriptorSet(fileDescriptorSet *descriptor.FileDescriptorSet, file
 	newFileDescriptorSet.File = append(newFileDescriptorSet.File, fileDescriptorProto)
 	return newFileDescriptorSet, nil
 }
+
+// MergeFileDescriptorSets merges the given FileDescriptorSets into a single
+// FileDescriptorSet.
+//
+// FileDescriptorProtos with the same name are only included once, as the
+// same file will be included in multiple FileDescriptorSets if it is imported
+// from multiple directories. The first FileDescriptorProto with a given name wins.
+func MergeFileDescriptorSets(fileDescriptorSets ...*descriptor.FileDescriptorSet) *descriptor.FileDescriptorSet {
+	names := make(map[string]struct{})
+	newFileDescriptorSet := &descriptor.FileDescriptorSet{}
+	for _, fileDescriptorSet := range fileDescriptorSets {
+		for _, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+			if _, ok := names[fileDescriptorProto.GetName()]; ok {
+				continue
+			}
+			names[fileDescriptorProto.GetName()] = struct{}{}
+			newFileDescriptorSet.File = append(newFileDescriptorSet.File, fileDescriptorProto)
+		}
+	}
+	return newFileDescriptorSet
+}
@@ -56,10 +56,15 @@ type Runner interface {
 	DescriptorProto(args []string) error
 	FieldDescriptorProto(args []string) error
 	ServiceDescriptorProto(args []string) error
-	Lint(args []string, listAllLinters bool, listLinters bool) error
+	Lint(args []string, listAllLinters bool, listLinters bool, generateBaseline bool, fix bool, strict bool, gitBranch string, descriptorSetPath string) error
 	ListLintGroup(group string) error
 	ListAllLintGroups() error
+	LintExplain(id string) error
 	Format(args []string, overwrite, diffMode, lintMode, fix bool) error
+	BreakCheck(args []string, gitBranch string, descriptorSetPath string) error
+	BreakDescriptorSet(args []string, descriptorSetPath string) error
+	BreakSuggestVersion(args []string, gitBranch string, descriptorSetPath string) error
+	DiffAPI(args []string) error
 	BinaryToJSON(args []string) error
 	JSONToBinary(args []string) error
 	All(args []string, disableFormat, disableLint, fix bool) error
@@ -99,6 +104,13 @@ func RunnerWithJSON() RunnerOption {
 	}
 }
 
+// RunnerWithJUnit returns a RunnerOption that will print failures as a JUnit XML report.
+func RunnerWithJUnit() RunnerOption {
+	return func(runner *runner) {
+		runner.junit = true
+	}
+}
+
 // RunnerWithPrintFields returns a RunnerOption that uses the given colon-separated
 // print fields. The default is filename:line:column:message.
 func RunnerWithPrintFields(printFields string) RunnerOption {
@@ -38,13 +38,18 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/apidiff"
 	"github.com/uber/prototool/internal/cfginit"
+	"github.com/uber/prototool/internal/compatible"
 	"github.com/uber/prototool/internal/create"
+	"github.com/uber/prototool/internal/desc"
 	"github.com/uber/prototool/internal/diff"
 	"github.com/uber/prototool/internal/extract"
 	"github.com/uber/prototool/internal/file"
 	"github.com/uber/prototool/internal/format"
+	"github.com/uber/prototool/internal/git"
 	"github.com/uber/prototool/internal/grpc"
 	"github.com/uber/prototool/internal/lint"
 	"github.com/uber/prototool/internal/protoc"
@@ -73,6 +78,7 @@ type runner struct {
 	protocURL     string
 	printFields   string
 	json          bool
+	junit         bool
 }
 
 func newRunner(workDirPath string, input io.Reader, output io.Writer, options ...RunnerOption) *runner {
@@ -306,7 +312,21 @@ func (r *runner) compile(doGen, doFileDescriptorSet, dryRun bool, meta *meta) ([
 	if dryRun {
 		return nil, r.printCommands(doGen, meta.ProtoSet)
 	}
-	compileResult, err := r.newCompiler(doGen, doFileDescriptorSet).Compile(meta.ProtoSet)
+	return r.runCompiler(r.newCompiler(doGen, doFileDescriptorSet), meta)
+}
+
+// compileFileDescriptorSet compiles the ProtoSet with source code info and
+// returns a single FileDescriptorSet for all the directories in the ProtoSet.
+func (r *runner) compileFileDescriptorSet(meta *meta) (*descriptor.FileDescriptorSet, error) {
+	fileDescriptorSets, err := r.runCompiler(r.newCompiler(false, true, protoc.CompilerWithSourceCodeInfo()), meta)
+	if err != nil {
+		return nil, err
+	}
+	return desc.MergeFileDescriptorSets(fileDescriptorSets...), nil
+}
+
+func (r *runner) runCompiler(compiler protoc.Compiler, meta *meta) ([]*descriptor.FileDescriptorSet, error) {
+	compileResult, err := compiler.Compile(meta.ProtoSet)
 	if err != nil {
 		return nil, err
 	}
@@ -333,9 +353,12 @@ func (r *runner) printCommands(doGen bool, protoSet *file.ProtoSet) error {
 	return nil
 }
 
-func (r *runner) Lint(args []string, listAllLinters bool, listLinters bool) error {
-	if listAllLinters && listLinters {
-		return newExitErrorf(255, "can only set one of list-all-linters, list-linters")
+func (r *runner) Lint(args []string, listAllLinters bool, listLinters bool, generateBaseline bool, fix bool, strict bool, gitBranch string, descriptorSetPath string) error {
+	if (listAllLinters && listLinters) || (listAllLinters && generateBaseline) || (listLinters && generateBaseline) {
+		return newExitErrorf(255, "can only set one of list-all-linters, list-linters, generate-baseline")
+	}
+	if fix && (listAllLinters || listLinters || generateBaseline) {
+		return newExitErrorf(255, "fix can not be set with list-all-linters, list-linters, or generate-baseline")
 	}
 	if listAllLinters {
 		return r.listAllLinters()
@@ -343,28 +366,138 @@ func (r *runner) Lint(args []string, listAllLinters bool, listLinters bool) erro
 	if listLinters {
 		return r.listLinters()
 	}
+	if gitBranch != "" && descriptorSetPath != "" {
+		return newExitErrorf(255, "must set only one of git-branch or descriptor-set-path")
+	}
 	meta, err := r.getMeta(args, 1)
 	if err != nil {
 		return err
 	}
 	r.printAffectedFiles(meta)
-	if _, err := r.compile(false, false, false, meta); err != nil {
+	fileDescriptorSet, err := r.compileFileDescriptorSet(meta)
+	if err != nil {
 		return err
 	}
-	return r.lint(meta)
+	previousFileDescriptorSet, err := r.getLintPreviousFileDescriptorSet(args, gitBranch, descriptorSetPath)
+	if err != nil {
+		return err
+	}
+	if generateBaseline {
+		r.logger.Debug("calling LintRunner to generate baseline")
+		return r.newLintRunner(fileDescriptorSet, previousFileDescriptorSet).GenerateBaseline(meta.ProtoSet)
+	}
+	if fix {
+		if err := r.lintFix(meta, fileDescriptorSet, previousFileDescriptorSet); err != nil {
+			return err
+		}
+		// the files have to compile after the fixes are applied
+		fileDescriptorSet, err = r.compileFileDescriptorSet(meta)
+		if err != nil {
+			return err
+		}
+	}
+	return r.lint(meta, fileDescriptorSet, previousFileDescriptorSet, strict)
+}
+
+// getLintPreviousFileDescriptorSet returns the previous state of the files
+// to lint, either compiled from the same files at the given git branch or
+// read from the FileDescriptorSet at the given path.
+//
+// If neither is set, this returns nil.
+func (r *runner) getLintPreviousFileDescriptorSet(args []string, gitBranch string, descriptorSetPath string) (*descriptor.FileDescriptorSet, error) {
+	if descriptorSetPath != "" {
+		return readFileDescriptorSet(descriptorSetPath)
+	}
+	if gitBranch == "" {
+		return nil, nil
+	}
+	gitMeta, cleanup, err := r.getGitMeta(args, 1, gitBranch)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	if gitMeta == nil {
+		return &descriptor.FileDescriptorSet{}, nil
+	}
+	return r.compileFileDescriptorSet(gitMeta)
 }
 
-func (r *runner) lint(meta *meta) error {
+// lintFix applies the suggested edits of the lint failures that would be
+// printed, and formats and overwrites the edited files.
+//
+// Edits can be for other files than the failure, such as the edits that
+// update the references to a renamed message.
+func (r *runner) lintFix(meta *meta, fileDescriptorSet *descriptor.FileDescriptorSet, previousFileDescriptorSet *descriptor.FileDescriptorSet) error {
+	r.logger.Debug("calling LintRunner to fix failures")
+	failures, err := r.newLintRunner(fileDescriptorSet, previousFileDescriptorSet).Run(meta.ProtoSet)
+	if err != nil {
+		return err
+	}
+	filenameToEdits := make(map[string][]*text.Edit)
+	for _, failure := range failures {
+		shouldPrint, err := shouldPrintFailure(meta, failure.Filename)
+		if err != nil {
+			return err
+		}
+		if !shouldPrint {
+			continue
+		}
+		for _, edit := range failure.Edits {
+			filenameToEdits[edit.Filename] = append(filenameToEdits[edit.Filename], edit)
+		}
+	}
+	for _, protoFiles := range meta.ProtoSet.DirPathToFiles {
+		for _, protoFile := range protoFiles {
+			edits, ok := filenameToEdits[protoFile.DisplayPath]
+			if !ok {
+				continue
+			}
+			input, err := ioutil.ReadFile(protoFile.Path)
+			if err != nil {
+				return err
+			}
+			data, failures, err := r.newTransformer(false, edits...).Transform(protoFile.Path, input)
+			if err != nil {
+				return err
+			}
+			if len(failures) > 0 {
+				if err := r.printFailures(protoFile.DisplayPath, meta, failures...); err != nil {
+					return err
+				}
+				return newExitErrorf(255, "")
+			}
+			if !bytes.Equal(input, data) {
+				if err := ioutil.WriteFile(protoFile.Path, data, os.ModePerm); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// lint prints the lint failures.
+//
+// The FileDescriptorSet is the compiled ProtoSet with imports and
+// source code info, which is used by the descriptor linters and the
+// lint plugins. The previous FileDescriptorSet is the state of the
+// files at a git branch or snapshot, or nil if none was given.
+//
+// Only failures with the severity error result in a non-zero exit code,
+// or failures with the severity warning if strict is set.
+func (r *runner) lint(meta *meta, fileDescriptorSet *descriptor.FileDescriptorSet, previousFileDescriptorSet *descriptor.FileDescriptorSet, strict bool) error {
 	r.logger.Debug("calling LintRunner")
-	failures, err := r.newLintRunner().Run(meta.ProtoSet)
+	failures, err := r.newLintRunner(fileDescriptorSet, previousFileDescriptorSet).Run(meta.ProtoSet)
 	if err != nil {
 		return err
 	}
 	if err := r.printFailures("", meta, failures...); err != nil {
 		return err
 	}
-	if len(failures) > 0 {
-		return newExitErrorf(255, "")
+	for _, failure := range failures {
+		if failure.Severity == text.SeverityError || (strict && failure.Severity == text.SeverityWarning) {
+			return newExitErrorf(255, "")
+		}
 	}
 	return nil
 }
@@ -386,18 +519,29 @@ func (r *runner) listAllLinters() error {
 }
 
 func (r *runner) ListLintGroup(group string) error {
-	linters, ok := lint.GroupToLinters[strings.ToLower(group)]
-	if !ok {
-		return newExitErrorf(255, "unknown lint group: %s", strings.ToLower(group))
+	config, err := r.getConfig(r.workDirPath)
+	if err != nil {
+		return err
+	}
+	linters, err := lint.GetGroupLinters(config.Lint, strings.ToLower(group))
+	if err != nil {
+		return newExitErrorf(255, "%v", err)
 	}
 	return r.printLinters(linters)
 }
 
 func (r *runner) ListAllLintGroups() error {
-	groups := make([]string, 0, len(lint.GroupToLinters))
+	config, err := r.getConfig(r.workDirPath)
+	if err != nil {
+		return err
+	}
+	groups := make([]string, 0, len(lint.GroupToLinters)+len(config.Lint.Groups))
 	for group := range lint.GroupToLinters {
 		groups = append(groups, group)
 	}
+	for group := range config.Lint.Groups {
+		groups = append(groups, group)
+	}
 	sort.Strings(groups)
 	for _, group := range groups {
 		if err := r.println(group); err != nil {
@@ -407,6 +551,82 @@ func (r *runner) ListAllLintGroups() error {
 	return nil
 }
 
+func (r *runner) LintExplain(id string) error {
+	id = strings.ToUpper(id)
+	var linter lint.Linter
+	for _, candidate := range lint.AllLinters {
+		if candidate.ID() == id {
+			linter = candidate
+			break
+		}
+	}
+	if linter == nil {
+		return newExitErrorf(255, "unknown lint id %s", id)
+	}
+	explanation, err := lint.GetExplanation(id)
+	if err != nil {
+		return err
+	}
+	inDefaultGroup := "no"
+	for _, defaultLinter := range lint.DefaultLinters {
+		if defaultLinter.ID() == id {
+			inDefaultGroup = "yes"
+			break
+		}
+	}
+	buffer := bytes.NewBuffer(nil)
+	fmt.Fprintf(buffer, "%s\n\n%s\n\nIn the default group: %s\n\n%s\n", linter.ID(), linter.Purpose(), inDefaultGroup, explanation.Rationale)
+	if parameterizedLinter, ok := linter.(lint.ParameterizedLinter); ok {
+		buffer.WriteString("\nParameters:\n\n")
+		tabWriter := newTabWriter(buffer)
+		for _, parameter := range parameterizedLinter.Parameters() {
+			fmt.Fprintf(tabWriter, "  %s\t%s\n", parameter.Name, getParameterDescription(parameter))
+		}
+		if err := tabWriter.Flush(); err != nil {
+			return err
+		}
+	}
+	if len(explanation.Parameters) > 0 {
+		buffer.WriteString("\nThe examples are linted with the parameters:\n\n")
+		names := make([]string, 0, len(explanation.Parameters))
+		for name := range explanation.Parameters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(buffer, "  %s: %s\n", name, explanation.Parameters[name])
+		}
+	}
+	if explanation.Previous != nil {
+		writeExample(buffer, "Previous state of the examples", explanation.Previous)
+	}
+	writeExample(buffer, "Failing example", explanation.Bad)
+	writeExample(buffer, "Passing example", explanation.Good)
+	_, err = r.output.Write(buffer.Bytes())
+	return err
+}
+
+// writeExample writes the files of the example sorted by file path,
+// with each file preceded by a comment containing its path.
+func writeExample(buffer *bytes.Buffer, title string, filePathToData map[string]string) {
+	filePaths := make([]string, 0, len(filePathToData))
+	for filePath := range filePathToData {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+	fmt.Fprintf(buffer, "\n%s:\n", title)
+	for _, filePath := range filePaths {
+		fmt.Fprintf(buffer, "\n  // %s\n", filePath)
+		for _, line := range strings.Split(strings.TrimSpace(filePathToData[filePath]), "\n") {
+			if line == "" {
+				buffer.WriteString("\n")
+			} else {
+				fmt.Fprintf(buffer, "  %s\n", line)
+			}
+		}
+	}
+}
+
 func (r *runner) Format(args []string, overwrite, diffMode, lintMode, fix bool) error {
 	if (overwrite && diffMode) || (overwrite && lintMode) || (diffMode && lintMode) {
 		return newExitErrorf(255, "can only set one of overwrite, diff, lint")
@@ -500,6 +720,364 @@ func (r *runner) formatFile(overwrite bool, diffMode bool, lintMode bool, fix bo
 	return true, nil
 }
 
+func (r *runner) BreakCheck(args []string, gitBranch string, descriptorSetPath string) error {
+	if r.json && r.junit {
+		return newExitErrorf(255, "must set only one of json or junit")
+	}
+	from, to, metas, cleanup, err := r.getBreakFileDescriptorSets(args, gitBranch, descriptorSetPath)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return r.breakCheck(from, to, metas...)
+}
+
+func (r *runner) BreakSuggestVersion(args []string, gitBranch string, descriptorSetPath string) error {
+	from, to, metas, cleanup, err := r.getBreakFileDescriptorSets(args, gitBranch, descriptorSetPath)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return r.breakSuggestVersion(from, to, metas...)
+}
+
+func (r *runner) BreakDescriptorSet(args []string, descriptorSetPath string) error {
+	if descriptorSetPath == "" {
+		return newExitErrorf(255, "must set descriptor-set-path")
+	}
+	meta, err := r.getMeta(args, 1)
+	if err != nil {
+		return err
+	}
+	r.printAffectedFiles(meta)
+	fileDescriptorSet, err := r.compileFileDescriptorSet(meta)
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(fileDescriptorSet)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(descriptorSetPath, data, 0644)
+}
+
+// getBreakFileDescriptorSets compiles the FileDescriptorSets to compare for
+// breaking changes, either against the given git branch or against the
+// FileDescriptorSet at the given path.
+//
+// The returned metas are the metas of the FileDescriptorSets, the first
+// being the meta of the working tree. The returned cleanup function must
+// always be called if there is no error.
+func (r *runner) getBreakFileDescriptorSets(args []string, gitBranch string, descriptorSetPath string) (*descriptor.FileDescriptorSet, *descriptor.FileDescriptorSet, []*meta, func(), error) {
+	if gitBranch == "" && descriptorSetPath == "" {
+		return nil, nil, nil, nil, newExitErrorf(255, "must set one of git-branch or descriptor-set-path")
+	}
+	if gitBranch != "" && descriptorSetPath != "" {
+		return nil, nil, nil, nil, newExitErrorf(255, "must set only one of git-branch or descriptor-set-path")
+	}
+	workMeta, err := r.getMeta(args, 1)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	r.printAffectedFiles(workMeta)
+	if descriptorSetPath != "" {
+		from, err := readFileDescriptorSet(descriptorSetPath)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		to, err := r.compileFileDescriptorSet(workMeta)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		return from, to, []*meta{workMeta}, func() {}, nil
+	}
+	gitMeta, cleanup, err := r.getGitMeta(args, 1, gitBranch)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	from := &descriptor.FileDescriptorSet{}
+	if gitMeta != nil {
+		if from, err = r.compileFileDescriptorSet(gitMeta); err != nil {
+			cleanup()
+			return nil, nil, nil, nil, err
+		}
+	}
+	to, err := r.compileFileDescriptorSet(workMeta)
+	if err != nil {
+		cleanup()
+		return nil, nil, nil, nil, err
+	}
+	return from, to, []*meta{workMeta, gitMeta}, cleanup, nil
+}
+
+// getBreakCheckOptions returns the compatible.CheckOptions and the
+// severity that results in a failure for the break config of the meta.
+func getBreakCheckOptions(meta *meta) ([]compatible.CheckOption, compatible.Severity, error) {
+	breakConfig := meta.ProtoSet.Config.Break
+	failSeverity := compatible.Source
+	if breakConfig.FailSeverity != "" {
+		failSeverity = compatible.Severity(breakConfig.FailSeverity)
+	}
+	checkOptions := []compatible.CheckOption{
+		compatible.CheckWithIgnorePackages(breakConfig.IgnorePackages...),
+	}
+	if breakConfig.IgnoreUnstablePackages {
+		checkOptions = append(checkOptions, compatible.CheckWithIgnoreUnstablePackages())
+	}
+	if breakConfig.PackageScope {
+		checkOptions = append(checkOptions, compatible.CheckWithPackageScope())
+	}
+	for _, filePath := range breakConfig.IgnoreFilePaths {
+		// FileDescriptorProto names are relative to the include path, which
+		// is the config directory unless otherwise specified
+		relFilePath, err := filepath.Rel(meta.ProtoSet.Config.DirPath, filePath)
+		if err != nil {
+			return nil, "", err
+		}
+		checkOptions = append(checkOptions, compatible.CheckWithIgnoreFiles(filepath.ToSlash(relFilePath)))
+	}
+	return checkOptions, failSeverity, nil
+}
+
+// breakCheck runs compatible.Check and prints the resulting errors.
+//
+// The break config of the first meta is used. The metas are used to map the
+// names of the FileDescriptorProtos to the display paths of the files they
+// were compiled from.
+func (r *runner) breakCheck(from *descriptor.FileDescriptorSet, to *descriptor.FileDescriptorSet, metas ...*meta) error {
+	checkOptions, failSeverity, err := getBreakCheckOptions(metas[0])
+	if err != nil {
+		return err
+	}
+	breaking := false
+	var errs compatible.Errors
+	for _, e := range compatible.Check(from, to, checkOptions...) {
+		if e.Severity.IsAtLeast(failSeverity) {
+			breaking = true
+		}
+		e.Filename = getBreakFilename(e.Filename, metas...)
+		shouldPrint, err := shouldPrintFailure(metas[0], e.Filename)
+		if err != nil {
+			return err
+		}
+		if shouldPrint {
+			errs = append(errs, e)
+		}
+	}
+	sort.Sort(errs)
+	if err := r.printBreakErrors(metas[0], errs, failSeverity); err != nil {
+		return err
+	}
+	if breaking {
+		return newExitErrorf(255, "")
+	}
+	return nil
+}
+
+// printBreakErrors prints the errors as text, as JSON lines, or as a JUnit XML report.
+//
+// Errors below the fail severity are reported as passing tests in the JUnit XML report.
+func (r *runner) printBreakErrors(meta *meta, errs []compatible.Error, failSeverity compatible.Severity) error {
+	if r.junit {
+		return compatible.PrintJUnitXML(r.output, errs, failSeverity)
+	}
+	if r.json {
+		bufWriter := bufio.NewWriter(r.output)
+		for _, e := range errs {
+			data, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(bufWriter, string(data)); err != nil {
+				return err
+			}
+		}
+		return bufWriter.Flush()
+	}
+	failures := make([]*text.Failure, 0, len(errs))
+	for _, e := range errs {
+		failures = append(failures, newBreakFailure(e))
+	}
+	return r.printFailures("", meta, failures...)
+}
+
+// breakSuggestVersion prints the semantic version bump suggested by
+// compatible.SuggestVersionBump, along with a changelog of the breaking
+// changes, additions and other changes grouped by package.
+//
+// The metas are used as for breakCheck.
+func (r *runner) breakSuggestVersion(from *descriptor.FileDescriptorSet, to *descriptor.FileDescriptorSet, metas ...*meta) error {
+	checkOptions, failSeverity, err := getBreakCheckOptions(metas[0])
+	if err != nil {
+		return err
+	}
+	errs := compatible.Check(from, to, checkOptions...)
+	additions := compatible.CheckAdditions(from, to, checkOptions...)
+
+	// errors are located in the original files, and additions in the updated files
+	fromPackages := getFilenameToPackage(from)
+	toPackages := getFilenameToPackage(to)
+	packageToChangelog := make(map[string]*breakChangelog)
+	getChangelog := func(pkg string) *breakChangelog {
+		changelog, ok := packageToChangelog[pkg]
+		if !ok {
+			changelog = &breakChangelog{Package: pkg}
+			packageToChangelog[pkg] = changelog
+		}
+		return changelog
+	}
+	for _, err := range errs {
+		changelog := getChangelog(fromPackages[err.Filename])
+		if err.Severity.IsAtLeast(failSeverity) {
+			changelog.Breaking = append(changelog.Breaking, newBreakFailure(err, metas...))
+		} else {
+			changelog.Other = append(changelog.Other, newBreakFailure(err, metas...))
+		}
+	}
+	for _, addition := range additions {
+		changelog := getChangelog(toPackages[addition.Filename])
+		changelog.Additions = append(changelog.Additions, &text.Failure{
+			Filename: getBreakFilename(addition.Filename, metas...),
+			Line:     int(addition.Line),
+			Column:   int(addition.Column),
+			Message:  addition.Message,
+		})
+	}
+	changelogs := make([]*breakChangelog, 0, len(packageToChangelog))
+	for _, changelog := range packageToChangelog {
+		text.SortFailures(changelog.Breaking)
+		text.SortFailures(changelog.Additions)
+		text.SortFailures(changelog.Other)
+		changelogs = append(changelogs, changelog)
+	}
+	sort.Slice(changelogs, func(i int, j int) bool { return changelogs[i].Package < changelogs[j].Package })
+	versionBump := compatible.SuggestVersionBump(errs, additions, failSeverity)
+
+	if r.json {
+		enc := json.NewEncoder(r.output)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			VersionBump compatible.VersionBump `json:"version_bump"`
+			Packages    []*breakChangelog      `json:"packages"`
+		}{
+			VersionBump: versionBump,
+			Packages:    changelogs,
+		})
+	}
+
+	bufWriter := bufio.NewWriter(r.output)
+	if _, err := fmt.Fprintf(bufWriter, "Suggested version bump: %s\n", versionBump); err != nil {
+		return err
+	}
+	for _, changelog := range changelogs {
+		pkg := changelog.Package
+		if pkg == "" {
+			pkg = "<no package>"
+		}
+		if _, err := fmt.Fprintf(bufWriter, "\n## %s\n", pkg); err != nil {
+			return err
+		}
+		for _, section := range []struct {
+			title    string
+			failures []*text.Failure
+		}{
+			{"Breaking changes", changelog.Breaking},
+			{"Additions", changelog.Additions},
+			{"Other changes", changelog.Other},
+		} {
+			if len(section.failures) == 0 {
+				continue
+			}
+			if _, err := fmt.Fprintf(bufWriter, "\n### %s\n\n", section.title); err != nil {
+				return err
+			}
+			for _, failure := range section.failures {
+				if _, err := bufWriter.WriteString("- "); err != nil {
+					return err
+				}
+				if err := failure.Fprintln(bufWriter); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return bufWriter.Flush()
+}
+
+// breakChangelog is the changelog of a package printed by breakSuggestVersion.
+type breakChangelog struct {
+	Package   string          `json:"package"`
+	Breaking  []*text.Failure `json:"breaking,omitempty"`
+	Additions []*text.Failure `json:"additions,omitempty"`
+	Other     []*text.Failure `json:"other,omitempty"`
+}
+
+// newBreakFailure converts the compatible.Error to a text.Failure, with
+// the severity as the ID. The metas are used as for getBreakFilename.
+func newBreakFailure(err compatible.Error, metas ...*meta) *text.Failure {
+	return &text.Failure{
+		Filename: getBreakFilename(err.Filename, metas...),
+		Line:     int(err.Line),
+		Column:   int(err.Column),
+		LintID:   strings.ToUpper(string(err.Severity)),
+		Message:  err.Message,
+	}
+}
+
+// getFilenameToPackage returns a map from the name of each file
+// in the FileDescriptorSet to its package.
+func getFilenameToPackage(fileDescriptorSet *descriptor.FileDescriptorSet) map[string]string {
+	filenameToPackage := make(map[string]string, len(fileDescriptorSet.GetFile()))
+	for _, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+		filenameToPackage[fileDescriptorProto.GetName()] = fileDescriptorProto.GetPackage()
+	}
+	return filenameToPackage
+}
+
+func (r *runner) DiffAPI(args []string) error {
+	from, err := r.getDiffAPIFileDescriptorSet(args, args[len(args)-2])
+	if err != nil {
+		return err
+	}
+	to, err := r.getDiffAPIFileDescriptorSet(args, args[len(args)-1])
+	if err != nil {
+		return err
+	}
+	changes := apidiff.Diff(from, to)
+	if r.json {
+		bufWriter := bufio.NewWriter(r.output)
+		for _, change := range changes {
+			data, err := json.Marshal(change)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(bufWriter, string(data)); err != nil {
+				return err
+			}
+		}
+		return bufWriter.Flush()
+	}
+	return apidiff.PrintMarkdown(r.output, changes)
+}
+
+// getDiffAPIFileDescriptorSet returns the FileDescriptorSet at the given
+// path if it is a file, or compiles the files at the given git ref otherwise.
+func (r *runner) getDiffAPIFileDescriptorSet(args []string, fileOrGitRef string) (*descriptor.FileDescriptorSet, error) {
+	if fileInfo, err := os.Stat(fileOrGitRef); err == nil && fileInfo.Mode().IsRegular() {
+		return readFileDescriptorSet(fileOrGitRef)
+	}
+	gitMeta, cleanup, err := r.getGitMeta(args, 3, fileOrGitRef)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	if gitMeta == nil {
+		return &descriptor.FileDescriptorSet{}, nil
+	}
+	r.printAffectedFiles(gitMeta)
+	return r.compileFileDescriptorSet(gitMeta)
+}
+
 func (r *runner) BinaryToJSON(args []string) error {
 	path := args[len(args)-2]
 	data, err := r.getInputData(args[len(args)-1])
@@ -566,11 +1144,14 @@ func (r *runner) All(args []string, disableFormat, disableLint, fix bool) error
 			return err
 		}
 	}
-	if _, err := r.compile(true, false, false, meta); err != nil {
+	// the code is generated and the FileDescriptorSet to lint is
+	// compiled with the same protoc run
+	fileDescriptorSets, err := r.runCompiler(r.newCompiler(true, !disableLint, protoc.CompilerWithSourceCodeInfo()), meta)
+	if err != nil {
 		return err
 	}
 	if !disableLint {
-		return r.lint(meta)
+		return r.lint(meta, desc.MergeFileDescriptorSets(fileDescriptorSets...), nil, false)
 	}
 	return nil
 }
@@ -672,7 +1253,7 @@ func (r *runner) newDownloader(config settings.Config) (protoc.Downloader, error
 	return protoc.NewDownloader(config, downloaderOptions...)
 }
 
-func (r *runner) newCompiler(doGen bool, doFileDescriptorSet bool) protoc.Compiler {
+func (r *runner) newCompiler(doGen bool, doFileDescriptorSet bool, options ...protoc.CompilerOption) protoc.Compiler {
 	compilerOptions := []protoc.CompilerOption{
 		protoc.CompilerWithLogger(r.logger),
 	}
@@ -712,20 +1293,31 @@ func (r *runner) newCompiler(doGen bool, doFileDescriptorSet bool) protoc.Compil
 			protoc.CompilerWithFileDescriptorSet(),
 		)
 	}
+	compilerOptions = append(compilerOptions, options...)
 	return protoc.NewCompiler(compilerOptions...)
 }
 
-func (r *runner) newLintRunner() lint.Runner {
+// newLintRunner returns a new lint.Runner.
+//
+// The FileDescriptorSet is used by the descriptor linters and sent to the
+// lint plugins. The previous FileDescriptorSet is nil unless the files are
+// linted against a previous state.
+func (r *runner) newLintRunner(fileDescriptorSet *descriptor.FileDescriptorSet, previousFileDescriptorSet *descriptor.FileDescriptorSet) lint.Runner {
 	return lint.NewRunner(
 		lint.RunnerWithLogger(r.logger),
+		lint.RunnerWithFileDescriptorSet(fileDescriptorSet),
+		lint.RunnerWithPreviousFileDescriptorSet(previousFileDescriptorSet),
 	)
 }
 
-func (r *runner) newTransformer(fix bool) format.Transformer {
+func (r *runner) newTransformer(fix bool, edits ...*text.Edit) format.Transformer {
 	transformerOptions := []format.TransformerOption{format.TransformerWithLogger(r.logger)}
 	if fix {
 		transformerOptions = append(transformerOptions, format.TransformerWithFix())
 	}
+	if len(edits) > 0 {
+		transformerOptions = append(transformerOptions, format.TransformerWithEdits(edits...))
+	}
 	return format.NewTransformer(transformerOptions...)
 }
 
@@ -826,6 +1418,102 @@ func (r *runner) getMeta(args []string, lenOfArgsIfSpecified int) (*meta, error)
 	return nil, fmt.Errorf("%s is not a directory or a regular file", fileOrDir)
 }
 
+// getGitMeta is the equivalent of getMeta for the state of the
+// repository at the given git ref.
+//
+// The returned cleanup function must always be called.
+// If dirOrFile does not exist at the given ref, the returned meta is nil.
+func (r *runner) getGitMeta(args []string, lenOfArgsIfSpecified int, gitRef string) (_ *meta, _ func(), retErr error) {
+	fileOrDir := "."
+	if len(args) == lenOfArgsIfSpecified {
+		fileOrDir = args[0]
+	}
+	clone, err := git.TemporaryClone(r.logger, r.workDirPath, gitRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		if err := clone.Remove(); err != nil {
+			r.logger.Warn("could not remove git clone", zap.String("path", clone.DirPath), zap.Error(err))
+		}
+	}
+	defer func() {
+		if retErr != nil {
+			cleanup()
+		}
+	}()
+	workDirPath, err := clone.Path(r.workDirPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	cloneFileOrDir, err := clone.Path(fileOrDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	fileInfo, err := os.Stat(cloneFileOrDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			r.logger.Debug("path does not exist at git ref", zap.String("path", fileOrDir), zap.String("ref", gitRef))
+			return nil, cleanup, nil
+		}
+		return nil, nil, err
+	}
+	singleFilename := ""
+	dirPath := cloneFileOrDir
+	if !fileInfo.Mode().IsDir() {
+		if !fileInfo.Mode().IsRegular() {
+			return nil, nil, fmt.Errorf("%s is not a directory or a regular file", fileOrDir)
+		}
+		singleFilename = fileOrDir
+		dirPath = filepath.Dir(cloneFileOrDir)
+	}
+	protoSet, err := r.protoSetProvider.GetForDir(workDirPath, dirPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &meta{
+		ProtoSet:       protoSet,
+		SingleFilename: singleFilename,
+	}, cleanup, nil
+}
+
+func readFileDescriptorSet(filePath string) (*descriptor.FileDescriptorSet, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	fileDescriptorSet := &descriptor.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, fileDescriptorSet); err != nil {
+		return nil, fmt.Errorf("could not read FileDescriptorSet from %s: %v", filePath, err)
+	}
+	return fileDescriptorSet, nil
+}
+
+// getBreakFilename returns the display path of the file that has the given
+// FileDescriptorProto name, or the name if no single file can be found.
+func getBreakFilename(name string, metas ...*meta) string {
+	displayPath := ""
+	for _, meta := range metas {
+		if meta == nil {
+			continue
+		}
+		for _, protoFiles := range meta.ProtoSet.DirPathToFiles {
+			for _, protoFile := range protoFiles {
+				if protoFile.DisplayPath == name || strings.HasSuffix(protoFile.DisplayPath, string(filepath.Separator)+name) {
+					if displayPath != "" && displayPath != protoFile.DisplayPath {
+						return name
+					}
+					displayPath = protoFile.DisplayPath
+				}
+			}
+		}
+	}
+	if displayPath == "" {
+		return name
+	}
+	return displayPath
+}
+
 // TODO: we filter failures in dir mode in printFailures but above we count any failure
 // as an error with a non-zero exit code, seems inconsistent, this needs refactoring
 
@@ -845,22 +1533,9 @@ func (r *runner) printFailures(filename string, meta *meta, failures ...*text.Fa
 	text.SortFailures(failures)
 	bufWriter := bufio.NewWriter(r.output)
 	for _, failure := range failures {
-		shouldPrint := false
-		if meta.SingleFilename == "" || meta.SingleFilename == failure.Filename {
-			shouldPrint = true
-		} else if meta.SingleFilename != "" {
-			// TODO: the compiler may not return the rel path due to logic in bestFilePath
-			absSingleFilename, err := file.AbsClean(meta.SingleFilename)
-			if err != nil {
-				return err
-			}
-			absFailureFilename, err := file.AbsClean(failure.Filename)
-			if err != nil {
-				return err
-			}
-			if absSingleFilename == absFailureFilename {
-				shouldPrint = true
-			}
+		shouldPrint, err := shouldPrintFailure(meta, failure.Filename)
+		if err != nil {
+			return err
 		}
 		if shouldPrint {
 			if r.json {
@@ -879,6 +1554,25 @@ func (r *runner) printFailures(filename string, meta *meta, failures ...*text.Fa
 	return bufWriter.Flush()
 }
 
+// shouldPrintFailure returns true if failures for the given filename
+// should be printed, that is if the meta is not for a single file, or
+// the filename is the single file.
+func shouldPrintFailure(meta *meta, filename string) (bool, error) {
+	if meta.SingleFilename == "" || meta.SingleFilename == filename {
+		return true, nil
+	}
+	// TODO: the compiler may not return the rel path due to logic in bestFilePath
+	absSingleFilename, err := file.AbsClean(meta.SingleFilename)
+	if err != nil {
+		return false, err
+	}
+	absFailureFilename, err := file.AbsClean(filename)
+	if err != nil {
+		return false, err
+	}
+	return absSingleFilename == absFailureFilename, nil
+}
+
 func (r *runner) printLinters(linters []lint.Linter) error {
 	sort.Slice(linters, func(i int, j int) bool { return linters[i].ID() < linters[j].ID() })
 	tabWriter := newTabWriter(r.output)
@@ -886,10 +1580,31 @@ func (r *runner) printLinters(linters []lint.Linter) error {
 		if _, err := fmt.Fprintf(tabWriter, "%s\t%s\n", linter.ID(), linter.Purpose()); err != nil {
 			return err
 		}
+		parameterizedLinter, ok := linter.(lint.ParameterizedLinter)
+		if !ok {
+			continue
+		}
+		for _, parameter := range parameterizedLinter.Parameters() {
+			if _, err := fmt.Fprintf(tabWriter, "\t%s\t%s\n", parameter.Name, getParameterDescription(parameter)); err != nil {
+				return err
+			}
+		}
 	}
 	return tabWriter.Flush()
 }
 
+func getParameterDescription(parameter *lint.Parameter) string {
+	description := parameter.Description
+	if len(parameter.Values) > 0 {
+		if parameter.List {
+			description += fmt.Sprintf(" A comma-separated list of %s.", strings.Join(parameter.Values, ", "))
+		} else {
+			description += fmt.Sprintf(" One of %s.", strings.Join(parameter.Values, ", "))
+		}
+	}
+	return description + fmt.Sprintf(" Default: %q.", parameter.Default)
+}
+
 func (r *runner) printAffectedFiles(meta *meta) {
 	for _, files := range meta.ProtoSet.DirPathToFiles {
 		for _, file := range files {
@@ -100,6 +100,10 @@ func TestProtoSetProviderGetMultipleForFilesAll(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 			&ProtoSet{
@@ -140,6 +144,10 @@ func TestProtoSetProviderGetMultipleForFilesAll(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 			&ProtoSet{
@@ -178,6 +186,10 @@ func TestProtoSetProviderGetMultipleForFilesAll(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 		},
@@ -251,6 +263,10 @@ func TestProtoSetProviderGetMultipleForFilesSomeMissing(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 			&ProtoSet{
@@ -287,6 +303,10 @@ func TestProtoSetProviderGetMultipleForFilesSomeMissing(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 		},
@@ -357,6 +377,10 @@ func TestProtoSetProviderGetMultipleForDirCwdAsValidRel(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 			&ProtoSet{
@@ -393,6 +417,10 @@ func TestProtoSetProviderGetMultipleForDirCwdAsValidRel(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 			&ProtoSet{
@@ -425,6 +453,10 @@ func TestProtoSetProviderGetMultipleForDirCwdAsValidRel(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 		},
@@ -495,6 +527,10 @@ func TestProtoSetProviderGetMultipleForDirCwdAbs(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 			&ProtoSet{
@@ -531,6 +567,10 @@ func TestProtoSetProviderGetMultipleForDirCwdAbs(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 			&ProtoSet{
@@ -563,6 +603,10 @@ func TestProtoSetProviderGetMultipleForDirCwdAbs(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 		},
@@ -613,6 +657,10 @@ func TestProtoSetProviderGetMultipleForDirCwdSubRel(t *testing.T) {
 						GoPluginOptions: settings.GenGoPluginOptions{},
 						Plugins:         []settings.GenPlugin{},
 					},
+					Break: settings.BreakConfig{
+						IgnorePackages:  []string{},
+						IgnoreFilePaths: []string{},
+					},
 				},
 			},
 		},
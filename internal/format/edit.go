@@ -0,0 +1,157 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package format
+
+import (
+	"sort"
+	"strings"
+	"text/scanner"
+
+	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/text"
+)
+
+// applyEdits applies the edits to the elements of the descriptor.
+func applyEdits(descriptor *proto.Proto, edits []*text.Edit) {
+	if len(edits) == 0 {
+		return
+	}
+	sortedEdits := make([]*text.Edit, len(edits))
+	copy(sortedEdits, edits)
+	// an edit of "foo.Bar.Baz" has to be applied before an edit of
+	// "foo.Bar" for the same type, otherwise the former no longer matches
+	sort.SliceStable(sortedEdits, func(i int, j int) bool { return len(sortedEdits[i].Old) > len(sortedEdits[j].Old) })
+	for _, edit := range sortedEdits {
+		applyEdit(descriptor.Elements, edit)
+	}
+}
+
+func applyEdit(elements []proto.Visitee, edit *text.Edit) {
+	for _, element := range elements {
+		switch e := element.(type) {
+		case *proto.Comment:
+			editComment(e, edit)
+		case *proto.Syntax:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+		case *proto.Package:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+		case *proto.Import:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+		case *proto.Option:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+		case *proto.Message:
+			editComment(e.Comment, edit)
+			if e.IsExtend {
+				editType(&e.Name, e.Position, edit)
+			} else {
+				editName(&e.Name, e.Position, edit)
+			}
+			applyEdit(e.Elements, edit)
+		case *proto.Enum:
+			editComment(e.Comment, edit)
+			editName(&e.Name, e.Position, edit)
+			applyEdit(e.Elements, edit)
+		case *proto.EnumField:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+			editName(&e.Name, e.Position, edit)
+		case *proto.NormalField:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+			editName(&e.Name, e.Position, edit)
+			editType(&e.Type, e.Position, edit)
+		case *proto.MapField:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+			editName(&e.Name, e.Position, edit)
+			editType(&e.Type, e.Position, edit)
+		case *proto.Oneof:
+			editComment(e.Comment, edit)
+			editName(&e.Name, e.Position, edit)
+			applyEdit(e.Elements, edit)
+		case *proto.OneOfField:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+			editName(&e.Name, e.Position, edit)
+			editType(&e.Type, e.Position, edit)
+		case *proto.Group:
+			editComment(e.Comment, edit)
+			editName(&e.Name, e.Position, edit)
+			applyEdit(e.Elements, edit)
+		case *proto.Service:
+			editComment(e.Comment, edit)
+			editName(&e.Name, e.Position, edit)
+			applyEdit(e.Elements, edit)
+		case *proto.RPC:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+			editName(&e.Name, e.Position, edit)
+			if !editType(&e.RequestType, e.Position, edit) {
+				editType(&e.ReturnsType, e.Position, edit)
+			}
+		case *proto.Reserved:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+		case *proto.Extensions:
+			editComment(e.Comment, edit)
+			editComment(e.InlineComment, edit)
+		}
+	}
+}
+
+// editName applies the edit to the name of the element at the position.
+func editName(name *string, position scanner.Position, edit *text.Edit) {
+	if edit.Reference || !isEditPosition(position, edit) || *name != edit.Old {
+		return
+	}
+	*name = edit.New
+}
+
+// editType applies the edit to a type referenced by the element at
+// the position, returning true if the edit was applied.
+func editType(typ *string, position scanner.Position, edit *text.Edit) bool {
+	if !edit.Reference || !isEditPosition(position, edit) {
+		return false
+	}
+	if *typ != edit.Old && !strings.HasPrefix(*typ, edit.Old+".") {
+		return false
+	}
+	*typ = edit.New + strings.TrimPrefix(*typ, edit.Old)
+	return true
+}
+
+// editComment applies the edit to the comment if it is an edit
+// from a C-style comment to a C++-style comment.
+func editComment(comment *proto.Comment, edit *text.Edit) {
+	if comment == nil || !comment.Cstyle || !isEditPosition(comment.Position, edit) {
+		return
+	}
+	if !edit.Reference && edit.Old == "/*" && edit.New == "//" {
+		comment.Cstyle = false
+	}
+}
+
+func isEditPosition(position scanner.Position, edit *text.Edit) bool {
+	return position.Line == edit.Line && position.Column == edit.Column
+}
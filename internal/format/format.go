@@ -55,6 +55,16 @@ func TransformerWithFix() TransformerOption {
 	}
 }
 
+// TransformerWithEdits returns a TransformerOption that applies the given
+// edits before formatting, such as the edits that fix lint failures.
+//
+// The edits are expected to be for the file that is transformed.
+func TransformerWithEdits(edits ...*text.Edit) TransformerOption {
+	return func(transformer *transformer) {
+		transformer.edits = append(transformer.edits, edits...)
+	}
+}
+
 // NewTransformer returns a new Transformer.
 func NewTransformer(options ...TransformerOption) Transformer {
 	return newTransformer(options...)
@@ -33,6 +33,7 @@ import (
 type transformer struct {
 	logger *zap.Logger
 	fix    bool
+	edits  []*text.Edit
 }
 
 func newTransformer(options ...TransformerOption) *transformer {
@@ -51,6 +52,7 @@ func (t *transformer) Transform(filename string, data []byte) ([]byte, []*text.F
 		return nil, nil, err
 	}
 	descriptor.Filename = filename
+	applyEdits(descriptor, t.edits)
 
 	firstPassVisitor := newFirstPassVisitor(filename, t.fix)
 	for _, element := range descriptor.Elements {
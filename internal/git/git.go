@@ -0,0 +1,148 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package git contains helpers to read the contents of a local git
+// repository at a given ref.
+//
+// This is used to compare the current state of a set of Protobuf files
+// against a previous state, for example for breaking change detection.
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Clone is a temporary clone of a local git repository.
+type Clone struct {
+	// RepoDirPath is the root directory of the original repository.
+	// Must be absolute.
+	RepoDirPath string
+	// DirPath is the root directory of the temporary clone.
+	// Must be absolute.
+	DirPath string
+	// Ref is the ref that is checked out in the clone.
+	Ref string
+}
+
+// TemporaryClone clones the git repository that contains dirPath into a
+// temporary directory and checks out the given ref.
+//
+// The ref can be anything that resolves to a commit in the repository
+// containing dirPath, such as a local branch, a tag, or a commit hash.
+// The working tree of the original repository is not modified.
+//
+// The caller is responsible for calling Remove on the returned Clone.
+func TemporaryClone(logger *zap.Logger, dirPath string, ref string) (_ *Clone, retErr error) {
+	if ref == "" {
+		return nil, fmt.Errorf("no git ref given")
+	}
+	if !filepath.IsAbs(dirPath) {
+		return nil, fmt.Errorf("%s is not an absolute path", dirPath)
+	}
+	repoDirPath, err := runGit(logger, dirPath, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not within a git repository: %v", dirPath, err)
+	}
+	// resolve the ref in the original repository so that local branches
+	// work, as these are only remote branches within the clone
+	commit, err := runGit(logger, repoDirPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve git ref %q: %v", ref, err)
+	}
+	cloneDirPath, err := ioutil.TempDir("", "prototool-git")
+	if err != nil {
+		return nil, err
+	}
+	clone := &Clone{
+		RepoDirPath: filepath.Clean(repoDirPath),
+		DirPath:     cloneDirPath,
+		Ref:         ref,
+	}
+	defer func() {
+		if retErr != nil {
+			_ = clone.Remove()
+		}
+	}()
+	// --shared uses the objects of the original repository directly
+	// instead of copying them, which makes this cheap for large repositories
+	if _, err := runGit(logger, cloneDirPath, "clone", "--quiet", "--shared", "--no-checkout", repoDirPath, cloneDirPath); err != nil {
+		return nil, err
+	}
+	if _, err := runGit(logger, cloneDirPath, "checkout", "--quiet", commit); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// Path returns the path within the clone that corresponds to the given
+// path within the original repository.
+//
+// Relative paths are resolved against the current working directory.
+func (c *Clone) Path(path string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	// git reports the repository root with symlinks resolved, for
+	// example /private/tmp instead of /tmp on Darwin, so try both
+	candidates := []string{absPath}
+	if evalPath, err := filepath.EvalSymlinks(absPath); err == nil && evalPath != absPath {
+		candidates = append(candidates, evalPath)
+	}
+	for _, candidate := range candidates {
+		relPath, err := filepath.Rel(c.RepoDirPath, candidate)
+		if err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			return filepath.Join(c.DirPath, relPath), nil
+		}
+	}
+	return "", fmt.Errorf("%s is not within the git repository %s", path, c.RepoDirPath)
+}
+
+// Remove removes the clone.
+func (c *Clone) Remove() error {
+	return os.RemoveAll(c.DirPath)
+}
+
+// runGit runs git with the given arguments within dirPath, and returns
+// the trimmed stdout.
+func runGit(logger *zap.Logger, dirPath string, args ...string) (string, error) {
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dirPath
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	logger.Debug("running git", zap.String("dirPath", dirPath), zap.Strings("args", args))
+	if err := cmd.Run(); err != nil {
+		if errString := strings.TrimSpace(stderr.String()); errString != "" {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), errString)
+		}
+		return "", fmt.Errorf("git %s: %v", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTemporaryClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repoDirPath, err := ioutil.TempDir("", "prototool-git-test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(repoDirPath) }()
+
+	runTestGit(t, repoDirPath, "init", "--quiet")
+	writeTestFile(t, filepath.Join(repoDirPath, "a", "foo.proto"), "old")
+	runTestGit(t, repoDirPath, "add", ".")
+	runTestGit(t, repoDirPath, "commit", "--quiet", "-m", "first")
+	runTestGit(t, repoDirPath, "branch", "old")
+	writeTestFile(t, filepath.Join(repoDirPath, "a", "foo.proto"), "new")
+	writeTestFile(t, filepath.Join(repoDirPath, "a", "bar.proto"), "new")
+
+	clone, err := TemporaryClone(zap.NewNop(), filepath.Join(repoDirPath, "a"), "old")
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, clone.Remove()) }()
+
+	clonePath, err := clone.Path(filepath.Join(repoDirPath, "a", "foo.proto"))
+	require.NoError(t, err)
+	data, err := ioutil.ReadFile(clonePath)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(data))
+	barClonePath, err := clone.Path(filepath.Join(repoDirPath, "a", "bar.proto"))
+	require.NoError(t, err)
+	_, err = os.Stat(barClonePath)
+	assert.True(t, os.IsNotExist(err))
+	// the working tree of the original repository is untouched
+	data, err = ioutil.ReadFile(filepath.Join(repoDirPath, "a", "foo.proto"))
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	_, err = clone.Path(os.TempDir())
+	assert.Error(t, err)
+	_, err = TemporaryClone(zap.NewNop(), repoDirPath, "does-not-exist")
+	assert.Error(t, err)
+}
+
+func runTestGit(t *testing.T, dirPath string, args ...string) {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@test.com"}, args...)...)
+	cmd.Dir = dirPath
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+}
+
+func writeTestFile(t *testing.T, filePath string, data string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+	require.NoError(t, ioutil.WriteFile(filePath, []byte(data), 0644))
+}
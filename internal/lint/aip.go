@@ -0,0 +1,252 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/scanner"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/location"
+	"github.com/uber/prototool/internal/strs"
+	"github.com/uber/prototool/internal/text"
+)
+
+// The standard methods of the Google API Improvement Proposals,
+// see https://google.aip.dev/130.
+const (
+	aipGet    = "Get"
+	aipList   = "List"
+	aipCreate = "Create"
+	aipUpdate = "Update"
+	aipDelete = "Delete"
+)
+
+const (
+	aipEmptyTypeName     = ".google.protobuf.Empty"
+	aipFieldMaskTypeName = ".google.protobuf.FieldMask"
+	aipOperationTypeName = ".google.longrunning.Operation"
+)
+
+// aipMethod is an RPC that is a standard method, which is an RPC whose
+// name is the standard method followed by the name of a resource,
+// such as GetFoo.
+type aipMethod struct {
+	// standardMethod is the standard method, such as Get.
+	standardMethod string
+	// resource is the name of the RPC without the standard method,
+	// such as Foo for GetFoo, or Foos for ListFoos.
+	resource string
+	// position is the position of the RPC.
+	position scanner.Position
+	// request and response are nil if the request or response type
+	// is not in the FileDescriptorSet.
+	request  *descriptorMessage
+	response *descriptorMessage
+
+	requestTypeName   string
+	responseTypeName  string
+	typeNameToMessage map[string]*descriptorMessage
+}
+
+// getAIPMethods returns the unary RPCs of the files that are the given
+// standard method. Streaming RPCs are not standard methods.
+func getAIPMethods(fileDescriptors []*FileDescriptor, standardMethod string) []*aipMethod {
+	var aipMethods []*aipMethod
+	for _, fileDescriptor := range fileDescriptors {
+		typeNameToMessage := getTypeNameToMessage(fileDescriptor.FileDescriptorSet)
+		for i, service := range fileDescriptor.GetService() {
+			for j, method := range service.GetMethod() {
+				if method.GetClientStreaming() || method.GetServerStreaming() {
+					continue
+				}
+				if !strings.HasPrefix(method.GetName(), standardMethod) {
+					continue
+				}
+				resource := strings.TrimPrefix(method.GetName(), standardMethod)
+				if !strs.IsCapitalized(resource) {
+					continue
+				}
+				aipMethods = append(aipMethods, &aipMethod{
+					standardMethod:    standardMethod,
+					resource:          resource,
+					position:          fileDescriptor.Position(location.Path{}.Scope(location.Service, i).Scope(location.Method, j)),
+					request:           typeNameToMessage[method.GetInputType()],
+					response:          typeNameToMessage[method.GetOutputType()],
+					requestTypeName:   method.GetInputType(),
+					responseTypeName:  method.GetOutputType(),
+					typeNameToMessage: typeNameToMessage,
+				})
+			}
+		}
+	}
+	return aipMethods
+}
+
+// checkRequestName checks that the request type is named after the RPC,
+// such as GetFooRequest for GetFoo.
+func (m *aipMethod) checkRequestName(add func(*text.Failure)) {
+	expectedName := m.standardMethod + m.resource + "Request"
+	if name := getMessageName(m.requestTypeName); name != expectedName {
+		add(text.NewFailuref(m.position, "", "Name of request type %q of %s RPC should be %q.", name, m.standardMethod, expectedName))
+	}
+}
+
+// checkResponseName checks that the response type is named after the RPC,
+// such as ListFoosResponse for ListFoos.
+func (m *aipMethod) checkResponseName(add func(*text.Failure)) {
+	expectedName := m.standardMethod + m.resource + "Response"
+	if name := getMessageName(m.responseTypeName); name != expectedName {
+		add(text.NewFailuref(m.position, "", "Name of response type %q of %s RPC should be %q.", name, m.standardMethod, expectedName))
+	}
+}
+
+// checkResponseIsResource checks that the response type is the resource,
+// or one of the other given type names.
+func (m *aipMethod) checkResponseIsResource(add func(*text.Failure), otherTypeNames ...string) {
+	if getMessageName(m.responseTypeName) == m.resource {
+		return
+	}
+	for _, otherTypeName := range otherTypeNames {
+		if m.responseTypeName == otherTypeName {
+			return
+		}
+	}
+	expected := []string{fmt.Sprintf("%q", m.resource)}
+	for _, otherTypeName := range otherTypeNames {
+		expected = append(expected, fmt.Sprintf("%q", strings.TrimPrefix(otherTypeName, ".")))
+	}
+	add(text.NewFailuref(m.position, "", "Response type %q of %s RPC should be %s.", getMessageName(m.responseTypeName), m.standardMethod, strings.Join(expected, " or ")))
+}
+
+// checkField checks that the message has the field.
+func (m *aipMethod) checkField(add func(*text.Failure), message *descriptorMessage, field *aipField) {
+	if message == nil {
+		return
+	}
+	if fieldDescriptorProto := getMessageField(message, field.name); fieldDescriptorProto != nil && field.matches(fieldDescriptorProto) {
+		return
+	}
+	add(text.NewFailuref(m.position, "", "Message %q of %s RPC should have a field %q of type %s.", message.GetName(), m.standardMethod, field.name, field.description))
+}
+
+// getResourceTypeName returns the type name of the message of the field
+// of the message, or the empty string if there is no such field or the
+// field is not a message.
+func (m *aipMethod) getResourceTypeName(message *descriptorMessage, fieldName string) string {
+	if message == nil {
+		return ""
+	}
+	fieldDescriptorProto := getMessageField(message, fieldName)
+	if fieldDescriptorProto == nil || fieldDescriptorProto.GetType() != descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+		return ""
+	}
+	return fieldDescriptorProto.GetTypeName()
+}
+
+// aipField is a field that a request or response type should have.
+type aipField struct {
+	name        string
+	description string
+	matches     func(*descriptor.FieldDescriptorProto) bool
+}
+
+// newAIPScalarField returns a new singular aipField of the scalar type.
+func newAIPScalarField(name string, fieldType descriptor.FieldDescriptorProto_Type) *aipField {
+	return &aipField{
+		name:        name,
+		description: strings.ToLower(strings.TrimPrefix(fieldType.String(), "TYPE_")),
+		matches: func(fieldDescriptorProto *descriptor.FieldDescriptorProto) bool {
+			return fieldDescriptorProto.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED && fieldDescriptorProto.GetType() == fieldType
+		},
+	}
+}
+
+// newAIPMessageField returns a new singular aipField of the message type,
+// given as a fully-qualified type name with a leading dot.
+func newAIPMessageField(name string, typeName string) *aipField {
+	return &aipField{
+		name:        name,
+		description: strings.TrimPrefix(typeName, "."),
+		matches: func(fieldDescriptorProto *descriptor.FieldDescriptorProto) bool {
+			return fieldDescriptorProto.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED && fieldDescriptorProto.GetTypeName() == typeName
+		},
+	}
+}
+
+// newAIPResourceField returns a new singular aipField of the resource,
+// which is a message named after the resource in any package.
+func newAIPResourceField(resource string) *aipField {
+	return &aipField{
+		name:        strs.ToLowerSnakeCase(resource),
+		description: resource,
+		matches: func(fieldDescriptorProto *descriptor.FieldDescriptorProto) bool {
+			return fieldDescriptorProto.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REPEATED &&
+				fieldDescriptorProto.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE &&
+				getMessageName(fieldDescriptorProto.GetTypeName()) == resource
+		},
+	}
+}
+
+// newAIPRepeatedResourceField returns a new repeated aipField of the
+// resources, which is a repeated message field named after the resources.
+func newAIPRepeatedResourceField(resources string) *aipField {
+	return &aipField{
+		name:        strs.ToLowerSnakeCase(resources),
+		description: "repeated message",
+		matches: func(fieldDescriptorProto *descriptor.FieldDescriptorProto) bool {
+			return fieldDescriptorProto.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED &&
+				fieldDescriptorProto.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE
+		},
+	}
+}
+
+// getMessageField returns the field of the message with the given name,
+// or nil if there is no such field.
+func getMessageField(message *descriptorMessage, name string) *descriptor.FieldDescriptorProto {
+	for _, fieldDescriptorProto := range message.GetField() {
+		if fieldDescriptorProto.GetName() == name {
+			return fieldDescriptorProto
+		}
+	}
+	return nil
+}
+
+// getMessageName returns the name of the message without its package
+// and enclosing messages, such as Bar for .foo.Bar.
+func getMessageName(typeName string) string {
+	if i := strings.LastIndex(typeName, "."); i >= 0 {
+		return typeName[i+1:]
+	}
+	return typeName
+}
+
+// getSortedTypeNames returns the keys of the map sorted.
+func getSortedTypeNames(typeNames map[string]*aipMethod) []string {
+	sortedTypeNames := make([]string, 0, len(typeNames))
+	for typeName := range typeNames {
+		sortedTypeNames = append(sortedTypeNames, typeName)
+	}
+	sort.Strings(sortedTypeNames)
+	return sortedTypeNames
+}
@@ -21,6 +21,7 @@
 package lint
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/emicklei/proto"
@@ -67,3 +68,192 @@ func (c *baseLinter) Check(dirPath string, descriptors []*proto.Proto) ([]*text.
 	}
 	return failures, err
 }
+
+type baseDescriptorLinter struct {
+	id       string
+	purpose  string
+	addCheck func(func(*text.Failure), string, []*FileDescriptor) error
+}
+
+func newBaseDescriptorLinter(
+	id string,
+	purpose string,
+	addCheck func(func(*text.Failure), string, []*FileDescriptor) error,
+) *baseDescriptorLinter {
+	return &baseDescriptorLinter{
+		id:       strings.ToUpper(id),
+		purpose:  purpose,
+		addCheck: addCheck,
+	}
+}
+
+func (c *baseDescriptorLinter) ID() string {
+	return c.id
+}
+
+func (c *baseDescriptorLinter) Purpose() string {
+	return c.purpose
+}
+
+func (c *baseDescriptorLinter) Check(string, []*proto.Proto) ([]*text.Failure, error) {
+	return nil, nil
+}
+
+func (c *baseDescriptorLinter) CheckFileDescriptors(dirPath string, fileDescriptors []*FileDescriptor) ([]*text.Failure, error) {
+	var failures []*text.Failure
+	err := c.addCheck(
+		func(failure *text.Failure) {
+			failures = append(failures, failure)
+		},
+		dirPath,
+		fileDescriptors,
+	)
+	for _, failure := range failures {
+		failure.LintID = c.id
+	}
+	return failures, err
+}
+
+type baseParameterizedLinter struct {
+	id         string
+	purpose    string
+	parameters []*Parameter
+	addCheck   func(func(*text.Failure), string, []*proto.Proto, map[string]string) error
+	values     map[string]string
+}
+
+func newBaseParameterizedLinter(
+	id string,
+	purpose string,
+	parameters []*Parameter,
+	addCheck func(func(*text.Failure), string, []*proto.Proto, map[string]string) error,
+) *baseParameterizedLinter {
+	values := make(map[string]string, len(parameters))
+	for _, parameter := range parameters {
+		values[parameter.Name] = parameter.Default
+	}
+	return &baseParameterizedLinter{
+		id:         strings.ToUpper(id),
+		purpose:    purpose,
+		parameters: parameters,
+		addCheck:   addCheck,
+		values:     values,
+	}
+}
+
+func (c *baseParameterizedLinter) ID() string {
+	return c.id
+}
+
+func (c *baseParameterizedLinter) Purpose() string {
+	return c.purpose
+}
+
+func (c *baseParameterizedLinter) Parameters() []*Parameter {
+	return c.parameters
+}
+
+func (c *baseParameterizedLinter) WithParameters(values map[string]string) (ParameterizedLinter, error) {
+	linter := newBaseParameterizedLinter(c.id, c.purpose, c.parameters, c.addCheck)
+	for name, value := range values {
+		parameter := getParameter(c.parameters, name)
+		if parameter == nil {
+			return nil, fmt.Errorf("unknown parameter %q for linter %s", name, c.id)
+		}
+		if err := validateParameterValue(parameter, value); err != nil {
+			return nil, fmt.Errorf("invalid value for parameter %q for linter %s: %v", name, c.id, err)
+		}
+		linter.values[name] = value
+	}
+	return linter, nil
+}
+
+func (c *baseParameterizedLinter) Check(dirPath string, descriptors []*proto.Proto) ([]*text.Failure, error) {
+	var failures []*text.Failure
+	err := c.addCheck(
+		func(failure *text.Failure) {
+			failures = append(failures, failure)
+		},
+		dirPath,
+		descriptors,
+		c.values,
+	)
+	for _, failure := range failures {
+		failure.LintID = c.id
+	}
+	return failures, err
+}
+
+type baseParameterizedDescriptorLinter struct {
+	id         string
+	purpose    string
+	parameters []*Parameter
+	addCheck   func(func(*text.Failure), string, []*FileDescriptor, map[string]string) error
+	values     map[string]string
+}
+
+func newBaseParameterizedDescriptorLinter(
+	id string,
+	purpose string,
+	parameters []*Parameter,
+	addCheck func(func(*text.Failure), string, []*FileDescriptor, map[string]string) error,
+) *baseParameterizedDescriptorLinter {
+	values := make(map[string]string, len(parameters))
+	for _, parameter := range parameters {
+		values[parameter.Name] = parameter.Default
+	}
+	return &baseParameterizedDescriptorLinter{
+		id:         strings.ToUpper(id),
+		purpose:    purpose,
+		parameters: parameters,
+		addCheck:   addCheck,
+		values:     values,
+	}
+}
+
+func (c *baseParameterizedDescriptorLinter) ID() string {
+	return c.id
+}
+
+func (c *baseParameterizedDescriptorLinter) Purpose() string {
+	return c.purpose
+}
+
+func (c *baseParameterizedDescriptorLinter) Parameters() []*Parameter {
+	return c.parameters
+}
+
+func (c *baseParameterizedDescriptorLinter) WithParameters(values map[string]string) (ParameterizedLinter, error) {
+	linter := newBaseParameterizedDescriptorLinter(c.id, c.purpose, c.parameters, c.addCheck)
+	for name, value := range values {
+		parameter := getParameter(c.parameters, name)
+		if parameter == nil {
+			return nil, fmt.Errorf("unknown parameter %q for linter %s", name, c.id)
+		}
+		if err := validateParameterValue(parameter, value); err != nil {
+			return nil, fmt.Errorf("invalid value for parameter %q for linter %s: %v", name, c.id, err)
+		}
+		linter.values[name] = value
+	}
+	return linter, nil
+}
+
+func (c *baseParameterizedDescriptorLinter) Check(string, []*proto.Proto) ([]*text.Failure, error) {
+	return nil, nil
+}
+
+func (c *baseParameterizedDescriptorLinter) CheckFileDescriptors(dirPath string, fileDescriptors []*FileDescriptor) ([]*text.Failure, error) {
+	var failures []*text.Failure
+	err := c.addCheck(
+		func(failure *text.Failure) {
+			failures = append(failures, failure)
+		},
+		dirPath,
+		fileDescriptors,
+		c.values,
+	)
+	for _, failure := range failures {
+		failure.LintID = c.id
+	}
+	return failures, err
+}
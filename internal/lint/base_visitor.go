@@ -65,6 +65,26 @@ func (v baseAddVisitor) AddFailuref(position scanner.Position, format string, ar
 	v.add(text.NewFailuref(position, "", format, args...))
 }
 
+// AddFailureWithEditsf adds a failure with the given suggested edits.
+func (v baseAddVisitor) AddFailureWithEditsf(position scanner.Position, edits []*text.Edit, format string, args ...interface{}) {
+	failure := text.NewFailuref(position, "", format, args...)
+	failure.Edits = edits
+	v.add(failure)
+}
+
+// AddRenameFailuref adds a failure with a suggested edit that renames
+// the element at the position from name to newName.
+//
+// If newName is empty or equal to name, no edit is suggested, as there
+// is no obvious fix.
+func (v baseAddVisitor) AddRenameFailuref(position scanner.Position, name string, newName string, format string, args ...interface{}) {
+	if newName == "" || newName == name {
+		v.AddFailuref(position, format, args...)
+		return
+	}
+	v.AddFailureWithEditsf(position, []*text.Edit{text.NewEdit(position, name, newName)}, format, args...)
+}
+
 // extendedVisitor extends the proto.Visitor interface.
 // extendedVisitors are expected to be called with one file at a time,
 // and are not thread-safe.
@@ -0,0 +1,223 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/text"
+)
+
+// baseline is the content of a baseline file.
+type baseline struct {
+	Failures []*baselineFailure `json:"failures"`
+}
+
+// baselineFailure is a failure in a baseline file.
+//
+// Failures are matched by file, lint ID, and element name rather than
+// by line number, so that unrelated edits to a file do not invalidate
+// the baseline.
+type baselineFailure struct {
+	// Filename is the path of the file relative to the
+	// directory of the configuration file.
+	Filename string `json:"filename"`
+	LintID   string `json:"lint_id"`
+	// Element is the name of the innermost element the failure is
+	// reported on, such as Foo.bar for field bar in message Foo.
+	// This is empty for failures that are not on an element.
+	Element string `json:"element,omitempty"`
+}
+
+// getBaselineFailures returns the baselineFailures for the failures.
+func getBaselineFailures(protoSet *file.ProtoSet, dirPathToDescriptors map[string][]*proto.Proto, failures []*text.Failure) ([]*baselineFailure, error) {
+	displayPathToRelPath, err := getDisplayPathToRelPath(protoSet)
+	if err != nil {
+		return nil, err
+	}
+	filenameToDescriptor := make(map[string]*proto.Proto)
+	for _, descriptors := range dirPathToDescriptors {
+		for _, descriptor := range descriptors {
+			filenameToDescriptor[descriptor.Filename] = descriptor
+		}
+	}
+	baselineFailures := make([]*baselineFailure, len(failures))
+	for i, failure := range failures {
+		relPath, ok := displayPathToRelPath[failure.Filename]
+		if !ok {
+			relPath = filepath.ToSlash(failure.Filename)
+		}
+		var element string
+		if descriptor, ok := filenameToDescriptor[failure.Filename]; ok {
+			element = getElementName(descriptor.Elements, "", failure.Line)
+		}
+		baselineFailures[i] = &baselineFailure{
+			Filename: relPath,
+			LintID:   failure.LintID,
+			Element:  element,
+		}
+	}
+	return baselineFailures, nil
+}
+
+// getDisplayPathToRelPath returns the map from the display path of each
+// file to its path relative to the directory of the configuration file.
+func getDisplayPathToRelPath(protoSet *file.ProtoSet) (map[string]string, error) {
+	displayPathToRelPath := make(map[string]string)
+	for _, protoFiles := range protoSet.DirPathToFiles {
+		for _, protoFile := range protoFiles {
+			relPath, err := filepath.Rel(protoSet.Config.DirPath, protoFile.Path)
+			if err != nil {
+				return nil, err
+			}
+			displayPathToRelPath[protoFile.DisplayPath] = filepath.ToSlash(relPath)
+		}
+	}
+	return displayPathToRelPath, nil
+}
+
+// getElementName returns the name of the innermost element that contains
+// the line, prefixed by the names of its parents, or the empty string if
+// no named element contains the line.
+func getElementName(elements []proto.Visitee, prefix string, line int) string {
+	for _, element := range elements {
+		position, _, children := getElementInfo(element)
+		if position.Line == 0 || line < position.Line || line > getElementEndLine(element) {
+			continue
+		}
+		name := getNameOfElement(element)
+		if name == "" {
+			return prefix
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		return getElementName(children, name, line)
+	}
+	return prefix
+}
+
+// getNameOfElement returns the name of the element, or the empty
+// string if the element does not have a name.
+func getNameOfElement(element proto.Visitee) string {
+	switch e := element.(type) {
+	case *proto.Package:
+		return e.Name
+	case *proto.Import:
+		return e.Filename
+	case *proto.Option:
+		return e.Name
+	case *proto.Message:
+		return e.Name
+	case *proto.Enum:
+		return e.Name
+	case *proto.EnumField:
+		return e.Name
+	case *proto.NormalField:
+		return e.Name
+	case *proto.MapField:
+		return e.Name
+	case *proto.Oneof:
+		return e.Name
+	case *proto.OneOfField:
+		return e.Name
+	case *proto.Group:
+		return e.Name
+	case *proto.Service:
+		return e.Name
+	case *proto.RPC:
+		return e.Name
+	default:
+		return ""
+	}
+}
+
+// filterBaseline returns the failures that are not in the baseline.
+//
+// Each baseline failure matches at most one failure, so that new failures
+// of the same lint ID on the same element are still reported.
+func filterBaseline(protoSet *file.ProtoSet, dirPathToDescriptors map[string][]*proto.Proto, failures []*text.Failure, baselineFailures []*baselineFailure) ([]*text.Failure, error) {
+	if len(baselineFailures) == 0 {
+		return failures, nil
+	}
+	failureBaselineFailures, err := getBaselineFailures(protoSet, dirPathToDescriptors, failures)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[baselineFailure]int, len(baselineFailures))
+	for _, baselineFailure := range baselineFailures {
+		counts[*baselineFailure]++
+	}
+	var filteredFailures []*text.Failure
+	for i, failure := range failures {
+		key := *failureBaselineFailures[i]
+		if counts[key] > 0 {
+			counts[key]--
+			continue
+		}
+		filteredFailures = append(filteredFailures, failure)
+	}
+	return filteredFailures, nil
+}
+
+// readBaseline reads the baseline file at the given path.
+func readBaseline(filePath string) ([]*baselineFailure, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("lint baseline file %s does not exist, generate it with prototool lint --generate-baseline", filePath)
+		}
+		return nil, err
+	}
+	b := &baseline{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("could not parse lint baseline file %s: %v", filePath, err)
+	}
+	return b.Failures, nil
+}
+
+// writeBaseline writes the baseline file at the given path.
+func writeBaseline(filePath string, baselineFailures []*baselineFailure) error {
+	sort.Slice(baselineFailures, func(i int, j int) bool {
+		one, two := baselineFailures[i], baselineFailures[j]
+		if one.Filename != two.Filename {
+			return one.Filename < two.Filename
+		}
+		if one.LintID != two.LintID {
+			return one.LintID < two.LintID
+		}
+		return one.Element < two.Element
+	})
+	if baselineFailures == nil {
+		baselineFailures = []*baselineFailure{}
+	}
+	data, err := json.MarshalIndent(&baseline{Failures: baselineFailures}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, append(data, '\n'), 0644)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/text"
+)
+
+var aipDeleteRequestsLinter = NewDescriptorLinter(
+	"AIP_DELETE_REQUESTS",
+	`Verifies that Delete RPCs take a DeleteResourceRequest with a string field "name" and return google.protobuf.Empty, the resource or a google.longrunning.Operation, see https://google.aip.dev/135.`,
+	checkAIPDeleteRequests,
+)
+
+func checkAIPDeleteRequests(add func(*text.Failure), dirPath string, fileDescriptors []*FileDescriptor) error {
+	for _, method := range getAIPMethods(fileDescriptors, aipDelete) {
+		method.checkRequestName(add)
+		method.checkField(add, method.request, newAIPScalarField("name", descriptor.FieldDescriptorProto_TYPE_STRING))
+		method.checkResponseIsResource(add, aipEmptyTypeName, aipOperationTypeName)
+	}
+	return nil
+}
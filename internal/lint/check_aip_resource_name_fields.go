@@ -0,0 +1,82 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/strs"
+	"github.com/uber/prototool/internal/text"
+)
+
+var aipResourceNameFieldsLinter = NewDescriptorLinter(
+	"AIP_RESOURCE_NAME_FIELDS",
+	`Verifies that the resources of standard methods have a string field "name", see https://google.aip.dev/122.`,
+	checkAIPResourceNameFields,
+)
+
+func checkAIPResourceNameFields(add func(*text.Failure), dirPath string, fileDescriptors []*FileDescriptor) error {
+	// the resources are the messages returned by Get RPCs, the resource
+	// fields of Create and Update requests, and the repeated resource
+	// fields of List responses, keyed by type name, with the first RPC
+	// that uses them
+	typeNameToMethod := make(map[string]*aipMethod)
+	addResource := func(typeName string, method *aipMethod) {
+		if _, ok := typeNameToMethod[typeName]; !ok && typeName != "" {
+			typeNameToMethod[typeName] = method
+		}
+	}
+	for _, method := range getAIPMethods(fileDescriptors, aipGet) {
+		if getMessageName(method.responseTypeName) == method.resource {
+			addResource(method.responseTypeName, method)
+		}
+	}
+	for _, standardMethod := range []string{aipCreate, aipUpdate} {
+		for _, method := range getAIPMethods(fileDescriptors, standardMethod) {
+			if typeName := method.getResourceTypeName(method.request, strs.ToLowerSnakeCase(method.resource)); getMessageName(typeName) == method.resource {
+				addResource(typeName, method)
+			}
+		}
+	}
+	for _, method := range getAIPMethods(fileDescriptors, aipList) {
+		addResource(method.getResourceTypeName(method.response, strs.ToLowerSnakeCase(method.resource)), method)
+	}
+	nameField := newAIPScalarField("name", descriptor.FieldDescriptorProto_TYPE_STRING)
+	for _, typeName := range getSortedTypeNames(typeNameToMethod) {
+		method := typeNameToMethod[typeName]
+		message, ok := method.typeNameToMessage[typeName]
+		if !ok {
+			continue
+		}
+		if fieldDescriptorProto := getMessageField(message, nameField.name); fieldDescriptorProto != nil && nameField.matches(fieldDescriptorProto) {
+			continue
+		}
+		// report the failure on the resource if it is in one of the
+		// files, otherwise on the RPC
+		position := method.position
+		for _, fileDescriptor := range fileDescriptors {
+			if fileDescriptor.FileDescriptorProto == message.file {
+				position = fileDescriptor.Position(message.path)
+			}
+		}
+		add(text.NewFailuref(position, "", "Resource %q should have a field %q of type %s.", message.GetName(), nameField.name, nameField.description))
+	}
+	return nil
+}
@@ -143,7 +143,7 @@ func (v commentsNoCStyleVisitor) checkComments(position scanner.Position, commen
 	for _, comment := range comments {
 		if comment != nil {
 			if comment.Cstyle {
-				v.AddFailuref(position, "C-Style comments are not allowed.")
+				v.AddFailureWithEditsf(position, []*text.Edit{text.NewEdit(comment.Position, "/*", "//")}, "C-Style comments are not allowed.")
 			}
 		}
 	}
@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/text"
+)
+
+var deletedFieldsReservedLinter = NewDescriptorLinter(
+	"DELETED_FIELDS_RESERVED",
+	`Verifies that the numbers and names of fields deleted since the git branch or FileDescriptorSet given with "lint --git-branch" or "lint --descriptor-set-path" are reserved.`,
+	checkDeletedFieldsReserved,
+)
+
+func checkDeletedFieldsReserved(add func(*text.Failure), dirPath string, fileDescriptors []*FileDescriptor) error {
+	for _, fileDescriptor := range fileDescriptors {
+		if fileDescriptor.PreviousFileDescriptorSet == nil {
+			continue
+		}
+		typeNameToMessage := getTypeNameToMessage(fileDescriptor.FileDescriptorSet)
+		previousTypeNameToMessage := getTypeNameToMessage(fileDescriptor.PreviousFileDescriptorSet)
+		var typeNames []string
+		for typeName, message := range typeNameToMessage {
+			if message.file == fileDescriptor.FileDescriptorProto {
+				typeNames = append(typeNames, typeName)
+			}
+		}
+		sort.Strings(typeNames)
+		for _, typeName := range typeNames {
+			message := typeNameToMessage[typeName]
+			// messages that were added or moved to another package have no deleted fields
+			previousMessage, ok := previousTypeNameToMessage[typeName]
+			if !ok {
+				continue
+			}
+			checkDeletedFieldsReservedForMessage(add, fileDescriptor, message, previousMessage)
+		}
+	}
+	return nil
+}
+
+func checkDeletedFieldsReservedForMessage(add func(*text.Failure), fileDescriptor *FileDescriptor, message *descriptorMessage, previousMessage *descriptorMessage) {
+	numbers := make(map[int32]struct{})
+	names := make(map[string]struct{})
+	for _, field := range message.GetField() {
+		numbers[field.GetNumber()] = struct{}{}
+		names[field.GetName()] = struct{}{}
+	}
+	for _, name := range message.GetReservedName() {
+		names[name] = struct{}{}
+	}
+	for _, previousField := range previousMessage.GetField() {
+		if _, ok := numbers[previousField.GetNumber()]; ok {
+			continue
+		}
+		if !isReservedFieldNumber(message.DescriptorProto, previousField.GetNumber()) {
+			add(text.NewFailuref(fileDescriptor.Position(message.path), "", "Field %q was deleted from message %q without reserving its number %d.", previousField.GetName(), message.GetName(), previousField.GetNumber()))
+		}
+		if _, ok := names[previousField.GetName()]; !ok {
+			add(text.NewFailuref(fileDescriptor.Position(message.path), "", "Field %q was deleted from message %q without reserving its name.", previousField.GetName(), message.GetName()))
+		}
+	}
+}
+
+// isReservedFieldNumber returns true if the number is in one of the
+// reserved ranges of the message, whose ends are exclusive.
+func isReservedFieldNumber(descriptorProto *descriptor.DescriptorProto, number int32) bool {
+	for _, reservedRange := range descriptorProto.GetReservedRange() {
+		if number >= reservedRange.GetStart() && number < reservedRange.GetEnd() {
+			return true
+		}
+	}
+	return false
+}
@@ -54,6 +54,6 @@ func (v enumFieldNamesUpperSnakeCaseVisitor) VisitEnum(enum *proto.Enum) {
 
 func (v enumFieldNamesUpperSnakeCaseVisitor) VisitEnumField(field *proto.EnumField) {
 	if !strs.IsUpperSnakeCase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be UPPER_SNAKE_CASE.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strs.ToUpperSnakeCase, strs.IsUpperSnakeCase), "Field name %q must be UPPER_SNAKE_CASE.", field.Name)
 	}
 }
@@ -21,6 +21,8 @@
 package lint
 
 import (
+	"strings"
+
 	"github.com/emicklei/proto"
 	"github.com/uber/prototool/internal/strs"
 	"github.com/uber/prototool/internal/text"
@@ -54,6 +56,6 @@ func (v enumFieldNamesUppercaseVisitor) VisitEnum(enum *proto.Enum) {
 
 func (v enumFieldNamesUppercaseVisitor) VisitEnumField(field *proto.EnumField) {
 	if !strs.IsUppercase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be uppercase.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strings.ToUpper, strs.IsUppercase), "Field name %q must be uppercase.", field.Name)
 	}
 }
@@ -0,0 +1,62 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/text"
+)
+
+var enumFieldNumbersUniqueLinter = NewLinter(
+	"ENUM_FIELD_NUMBERS_UNIQUE",
+	`Verifies that no two enum fields of an enum have the same number, which is only allowed with the option "allow_alias".`,
+	checkEnumFieldNumbersUnique,
+)
+
+func checkEnumFieldNumbersUnique(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
+	return runVisitor(enumFieldNumbersUniqueVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+}
+
+type enumFieldNumbersUniqueVisitor struct {
+	baseAddVisitor
+}
+
+func (v enumFieldNumbersUniqueVisitor) VisitMessage(message *proto.Message) {
+	// for nested enums
+	for _, element := range message.Elements {
+		element.Accept(v)
+	}
+}
+
+func (v enumFieldNumbersUniqueVisitor) VisitEnum(enum *proto.Enum) {
+	numberToName := make(map[int]string)
+	for _, element := range enum.Elements {
+		enumField, ok := element.(*proto.EnumField)
+		if !ok {
+			continue
+		}
+		if name, ok := numberToName[enumField.Integer]; ok {
+			v.AddFailuref(enumField.Position, "Enum field %q has the same number %d as %q, give each enum field its own number instead of using aliases.", enumField.Name, enumField.Integer, name)
+			continue
+		}
+		numberToName[enumField.Integer] = enumField.Name
+	}
+}
@@ -63,6 +63,6 @@ func (v *enumFieldPrefixesVisitor) VisitEnum(enum *proto.Enum) {
 func (v *enumFieldPrefixesVisitor) VisitEnumField(enumField *proto.EnumField) {
 	expectedPrefix := strings.Join(v.nestedNames, "_") + "_"
 	if !strings.HasPrefix(enumField.Name, expectedPrefix) {
-		v.AddFailuref(enumField.Position, "Enum field %q is expected to have the prefix %q.", enumField.Name, expectedPrefix)
+		v.AddRenameFailuref(enumField.Position, enumField.Name, getUnusedEnumFieldName(enumField, expectedPrefix+enumField.Name), "Enum field %q is expected to have the prefix %q.", enumField.Name, expectedPrefix)
 	}
 }
@@ -65,6 +65,6 @@ func (v *enumFieldPrefixesExceptMessageVisitor) VisitEnumField(enumField *proto.
 	}
 	expectedPrefix := strs.ToUpperSnakeCase(enum.Name) + "_"
 	if !strings.HasPrefix(enumField.Name, expectedPrefix) {
-		v.AddFailuref(enumField.Position, "Enum field %q is expected to have the prefix %q.", enumField.Name, expectedPrefix)
+		v.AddRenameFailuref(enumField.Position, enumField.Name, getUnusedEnumFieldName(enumField, expectedPrefix+enumField.Name), "Enum field %q is expected to have the prefix %q.", enumField.Name, expectedPrefix)
 	}
 }
@@ -49,6 +49,6 @@ func (v enumNamesCamelCaseVisitor) VisitMessage(message *proto.Message) {
 
 func (v enumNamesCamelCaseVisitor) VisitEnum(enum *proto.Enum) {
 	if !strs.IsCamelCase(enum.Name) {
-		v.AddFailuref(enum.Position, "Enum name %q must be CamelCase.", enum.Name)
+		v.AddRenameFailuref(enum.Position, enum.Name, getFixedName(enum.Name, strs.ToUpperCamelCase, strs.IsCamelCase), "Enum name %q must be CamelCase.", enum.Name)
 	}
 }
@@ -49,6 +49,6 @@ func (v enumNamesCapitalizedVisitor) VisitMessage(message *proto.Message) {
 
 func (v enumNamesCapitalizedVisitor) VisitEnum(enum *proto.Enum) {
 	if !strs.IsCapitalized(enum.Name) {
-		v.AddFailuref(enum.Position, "Enum name %q must be capitalized.", enum.Name)
+		v.AddRenameFailuref(enum.Position, enum.Name, getFixedName(enum.Name, toCapitalized, strs.IsCapitalized), "Enum name %q must be capitalized.", enum.Name)
 	}
 }
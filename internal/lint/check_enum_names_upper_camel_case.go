@@ -49,6 +49,6 @@ func (v enumNamesUpperCamelCaseVisitor) VisitMessage(message *proto.Message) {
 
 func (v enumNamesUpperCamelCaseVisitor) VisitEnum(enum *proto.Enum) {
 	if !strs.IsUpperCamelCase(enum.Name) {
-		v.AddFailuref(enum.Position, "Enum name %q must be upper CamelCase.", enum.Name)
+		v.AddRenameFailuref(enum.Position, enum.Name, getFixedName(enum.Name, strs.ToUpperCamelCase, strs.IsUpperCamelCase), "Enum name %q must be upper CamelCase.", enum.Name)
 	}
 }
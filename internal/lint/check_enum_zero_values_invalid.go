@@ -28,19 +28,29 @@ import (
 	"github.com/uber/prototool/internal/text"
 )
 
-var enumZeroValuesInvalidLinter = NewLinter(
-	"ENUM_ZERO_VALUES_INVALID",
-	"Verifies that all enum zero value names are [NESTED_MESSAGE_NAME_]ENUM_NAME_INVALID.",
-	checkEnumZeroValuesInvalid,
+var (
+	enumZeroValuesInvalidLinter = NewParameterizedLinter(
+		"ENUM_ZERO_VALUES_INVALID",
+		"Verifies that all enum zero value names are [NESTED_MESSAGE_NAME_]ENUM_NAME_SUFFIX, where the suffix is INVALID by default.",
+		[]*Parameter{enumZeroValuesSuffixParameter},
+		checkEnumZeroValuesInvalid,
+	)
+
+	enumZeroValuesSuffixParameter = &Parameter{
+		Name:        "suffix",
+		Description: "The suffix of enum zero value names, such as UNSPECIFIED.",
+		Default:     "INVALID",
+	}
 )
 
-func checkEnumZeroValuesInvalid(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
-	return runVisitor(&enumZeroValuesInvalidVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkEnumZeroValuesInvalid(add func(*text.Failure), dirPath string, descriptors []*proto.Proto, parameters map[string]string) error {
+	return runVisitor(&enumZeroValuesInvalidVisitor{baseAddVisitor: newBaseAddVisitor(add), suffix: parameters[enumZeroValuesSuffixParameter.Name]}, descriptors)
 }
 
 type enumZeroValuesInvalidVisitor struct {
 	baseAddVisitor
 
+	suffix      string
 	nestedNames []string
 }
 
@@ -62,9 +72,9 @@ func (v *enumZeroValuesInvalidVisitor) VisitEnum(enum *proto.Enum) {
 
 func (v *enumZeroValuesInvalidVisitor) VisitEnumField(enumField *proto.EnumField) {
 	if enumField.Integer == 0 {
-		expectedName := strings.Join(v.nestedNames, "_") + "_INVALID"
+		expectedName := strings.Join(v.nestedNames, "_") + "_" + v.suffix
 		if enumField.Name != expectedName {
-			v.AddFailuref(enumField.Position, "Zero value enum field %q is expected to have the name %q.", enumField.Name, expectedName)
+			v.AddRenameFailuref(enumField.Position, enumField.Name, getUnusedEnumFieldName(enumField, expectedName), "Zero value enum field %q is expected to have the name %q.", enumField.Name, expectedName)
 		}
 	}
 }
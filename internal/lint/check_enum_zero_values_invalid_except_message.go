@@ -26,18 +26,21 @@ import (
 	"github.com/uber/prototool/internal/text"
 )
 
-var enumZeroValuesInvalidExceptMessageLinter = NewLinter(
+var enumZeroValuesInvalidExceptMessageLinter = NewParameterizedLinter(
 	"ENUM_ZERO_VALUES_INVALID_EXCEPT_MESSAGE",
-	"Verifies that all enum zero value names are ENUM_NAME_INVALID.",
+	"Verifies that all enum zero value names are ENUM_NAME_SUFFIX, where the suffix is INVALID by default.",
+	[]*Parameter{enumZeroValuesSuffixParameter},
 	checkEnumZeroValuesInvalidExceptMessage,
 )
 
-func checkEnumZeroValuesInvalidExceptMessage(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
-	return runVisitor(&enumZeroValuesInvalidExceptMessageVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkEnumZeroValuesInvalidExceptMessage(add func(*text.Failure), dirPath string, descriptors []*proto.Proto, parameters map[string]string) error {
+	return runVisitor(&enumZeroValuesInvalidExceptMessageVisitor{baseAddVisitor: newBaseAddVisitor(add), suffix: parameters[enumZeroValuesSuffixParameter.Name]}, descriptors)
 }
 
 type enumZeroValuesInvalidExceptMessageVisitor struct {
 	baseAddVisitor
+
+	suffix string
 }
 
 func (v *enumZeroValuesInvalidExceptMessageVisitor) VisitMessage(message *proto.Message) {
@@ -62,9 +65,9 @@ func (v *enumZeroValuesInvalidExceptMessageVisitor) VisitEnumField(enumField *pr
 		if !ok {
 			v.AddFailuref(enumField.Position, "System error. Enum field %q has no enum parent.", enumField.Name)
 		}
-		expectedName := strs.ToUpperSnakeCase(enum.Name) + "_INVALID"
+		expectedName := strs.ToUpperSnakeCase(enum.Name) + "_" + v.suffix
 		if enumField.Name != expectedName {
-			v.AddFailuref(enumField.Position, "Zero value enum field %q is expected to have the name %q.", enumField.Name, expectedName)
+			v.AddRenameFailuref(enumField.Position, enumField.Name, getUnusedEnumFieldName(enumField, expectedName), "Zero value enum field %q is expected to have the name %q.", enumField.Name, expectedName)
 		}
 	}
 }
@@ -21,25 +21,25 @@
 package lint
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/emicklei/proto"
 	"github.com/uber/prototool/internal/text"
 )
 
-var enumsHaveCommentsLinter = NewLinter(
+var enumsHaveCommentsLinter = NewParameterizedLinter(
 	"ENUMS_HAVE_COMMENTS",
 	`Verifies that all enums have a comment of the form "// EnumName ...".`,
+	[]*Parameter{commentPrefixParameter},
 	checkEnumsHaveComments,
 )
 
-func checkEnumsHaveComments(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
-	return runVisitor(enumsHaveCommentsVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkEnumsHaveComments(add func(*text.Failure), dirPath string, descriptors []*proto.Proto, parameters map[string]string) error {
+	return runVisitor(enumsHaveCommentsVisitor{baseAddVisitor: newBaseAddVisitor(add), prefix: parameters[commentPrefixParameter.Name]}, descriptors)
 }
 
 type enumsHaveCommentsVisitor struct {
 	baseAddVisitor
+
+	prefix string
 }
 
 func (v enumsHaveCommentsVisitor) VisitMessage(message *proto.Message) {
@@ -50,7 +50,7 @@ func (v enumsHaveCommentsVisitor) VisitMessage(message *proto.Message) {
 }
 
 func (v enumsHaveCommentsVisitor) VisitEnum(enum *proto.Enum) {
-	if enum.Comment == nil || len(enum.Comment.Lines) == 0 || !strings.HasPrefix(enum.Comment.Lines[0], fmt.Sprintf(" %s ", enum.Name)) {
-		v.AddFailuref(enum.Position, `Enum %q needs a comment of the form "// %s ..."`, enum.Name, enum.Name)
+	if !hasCommentPrefix(enum.Comment, enum.Name, v.prefix) {
+		v.AddFailuref(enum.Position, `Enum %q needs a comment of the form "// %s..."`, enum.Name, getCommentPrefix(enum.Name, v.prefix))
 	}
 }
@@ -0,0 +1,100 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/text"
+)
+
+// maxOneByteFieldNumber is the highest field number whose tag is
+// encoded in one byte.
+const maxOneByteFieldNumber = 15
+
+var fieldNumbersOneByteFirstLinter = NewLinter(
+	"FIELD_NUMBERS_ONE_BYTE_FIRST",
+	"Verifies that messages with field numbers above 15 use or reserve all the numbers 1 to 15, which are encoded in one byte.",
+	checkFieldNumbersOneByteFirst,
+)
+
+func checkFieldNumbersOneByteFirst(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
+	return runVisitor(fieldNumbersOneByteFirstVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+}
+
+type fieldNumbersOneByteFirstVisitor struct {
+	baseAddVisitor
+}
+
+func (v fieldNumbersOneByteFirstVisitor) VisitMessage(message *proto.Message) {
+	if message.IsExtend {
+		return
+	}
+	usedNumbers := make(map[int]struct{})
+	maxNumber := 0
+	addNumber := func(number int) {
+		usedNumbers[number] = struct{}{}
+		if number > maxNumber {
+			maxNumber = number
+		}
+	}
+	for _, element := range message.Elements {
+		switch element := element.(type) {
+		case *proto.NormalField:
+			addNumber(element.Sequence)
+		case *proto.MapField:
+			addNumber(element.Sequence)
+		case *proto.Oneof:
+			for _, oneofElement := range element.Elements {
+				if field, ok := oneofElement.(*proto.OneOfField); ok {
+					addNumber(field.Sequence)
+				}
+			}
+		case *proto.Reserved:
+			for _, r := range element.Ranges {
+				to := r.To
+				if r.Max || to > maxOneByteFieldNumber {
+					to = maxOneByteFieldNumber
+				}
+				for number := r.From; number <= to; number++ {
+					usedNumbers[number] = struct{}{}
+				}
+			}
+		}
+	}
+	if maxNumber > maxOneByteFieldNumber {
+		var unusedNumbers []string
+		for number := 1; number <= maxOneByteFieldNumber; number++ {
+			if _, ok := usedNumbers[number]; !ok {
+				unusedNumbers = append(unusedNumbers, strconv.Itoa(number))
+			}
+		}
+		if len(unusedNumbers) > 0 {
+			v.AddFailuref(message.Position, "Message %q uses field numbers above %d but not the numbers %s, which are encoded in one byte and should be used for the most frequently set fields.", message.Name, maxOneByteFieldNumber, strings.Join(unusedNumbers, ", "))
+		}
+	}
+	// for nested messages
+	for _, element := range message.Elements {
+		element.Accept(v)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"path/filepath"
+	"strings"
+	"text/scanner"
+
+	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/text"
+)
+
+var fileNamesNotReservedWordsLinter = NewLinter(
+	"FILE_NAMES_NOT_RESERVED_WORDS",
+	"Verifies that the file names are not reserved words of the protobuf language or of the languages that code is generated for.",
+	checkFileNamesNotReservedWords,
+)
+
+// reservedWords are the words that file names may not be, as the
+// generated files and classes are named after the files.
+var reservedWords = map[string]struct{}{
+	// the keywords and scalar types of the protobuf language
+	"bool":       {},
+	"bytes":      {},
+	"double":     {},
+	"enum":       {},
+	"extend":     {},
+	"extensions": {},
+	"false":      {},
+	"fixed32":    {},
+	"fixed64":    {},
+	"float":      {},
+	"group":      {},
+	"import":     {},
+	"int32":      {},
+	"int64":      {},
+	"map":        {},
+	"max":        {},
+	"message":    {},
+	"oneof":      {},
+	"option":     {},
+	"optional":   {},
+	"package":    {},
+	"public":     {},
+	"repeated":   {},
+	"required":   {},
+	"reserved":   {},
+	"returns":    {},
+	"rpc":        {},
+	"service":    {},
+	"sfixed32":   {},
+	"sfixed64":   {},
+	"sint32":     {},
+	"sint64":     {},
+	"stream":     {},
+	"string":     {},
+	"syntax":     {},
+	"to":         {},
+	"true":       {},
+	"uint32":     {},
+	"uint64":     {},
+	"weak":       {},
+	// the keywords of Go, that are not listed above
+	"break":       {},
+	"case":        {},
+	"chan":        {},
+	"const":       {},
+	"continue":    {},
+	"default":     {},
+	"defer":       {},
+	"else":        {},
+	"fallthrough": {},
+	"for":         {},
+	"func":        {},
+	"go":          {},
+	"goto":        {},
+	"if":          {},
+	"interface":   {},
+	"range":       {},
+	"return":      {},
+	"select":      {},
+	"struct":      {},
+	"switch":      {},
+	"type":        {},
+	"var":         {},
+	// the keywords and literals of Java, that are not listed above
+	"abstract":     {},
+	"assert":       {},
+	"boolean":      {},
+	"byte":         {},
+	"catch":        {},
+	"char":         {},
+	"class":        {},
+	"do":           {},
+	"extends":      {},
+	"final":        {},
+	"finally":      {},
+	"implements":   {},
+	"instanceof":   {},
+	"int":          {},
+	"long":         {},
+	"native":       {},
+	"new":          {},
+	"null":         {},
+	"private":      {},
+	"protected":    {},
+	"short":        {},
+	"static":       {},
+	"strictfp":     {},
+	"super":        {},
+	"synchronized": {},
+	"this":         {},
+	"throw":        {},
+	"throws":       {},
+	"transient":    {},
+	"try":          {},
+	"void":         {},
+	"volatile":     {},
+	"while":        {},
+	// the lowercase keywords of Python, that are not listed above
+	"and":      {},
+	"as":       {},
+	"async":    {},
+	"await":    {},
+	"def":      {},
+	"del":      {},
+	"elif":     {},
+	"except":   {},
+	"from":     {},
+	"global":   {},
+	"in":       {},
+	"is":       {},
+	"lambda":   {},
+	"nonlocal": {},
+	"not":      {},
+	"or":       {},
+	"pass":     {},
+	"raise":    {},
+	"with":     {},
+	"yield":    {},
+}
+
+func checkFileNamesNotReservedWords(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
+	for _, descriptor := range descriptors {
+		name := strings.TrimSuffix(filepath.Base(descriptor.Filename), ".proto")
+		if _, ok := reservedWords[name]; ok {
+			add(text.NewFailuref(scanner.Position{Filename: descriptor.Filename}, "", "File name %q must not be the reserved word %q.", filepath.Base(descriptor.Filename), name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"path"
+	"strings"
+
+	"github.com/uber/prototool/internal/location"
+	"github.com/uber/prototool/internal/text"
+)
+
+var filePathMatchesPackageLinter = NewDescriptorLinter(
+	"FILE_PATH_MATCHES_PACKAGE",
+	"Verifies that the directory of each file relative to its include path matches its package, such as foo/bar/v1 for package foo.bar.v1.",
+	checkFilePathMatchesPackage,
+)
+
+func checkFilePathMatchesPackage(add func(*text.Failure), dirPath string, fileDescriptors []*FileDescriptor) error {
+	for _, fileDescriptor := range fileDescriptors {
+		pkg := fileDescriptor.GetPackage()
+		if pkg == "" {
+			continue
+		}
+		// the name of the compiled file is relative to the include path
+		// that contains the file, or to the directory of the configuration
+		// file if no include path contains the file, see protoc.getIncludes
+		dir := path.Dir(fileDescriptor.GetName())
+		expectedDir := strings.Replace(pkg, ".", "/", -1)
+		if dir != expectedDir {
+			add(text.NewFailuref(fileDescriptor.Position(location.Path{}.Target(location.Package)), "", "File %q with package %q must be in the directory %q relative to the include path, but was in %q.", fileDescriptor.GetName(), pkg, expectedDir, dir))
+		}
+	}
+	return nil
+}
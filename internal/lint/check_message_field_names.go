@@ -0,0 +1,110 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"strings"
+	"text/scanner"
+
+	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/strs"
+	"github.com/uber/prototool/internal/text"
+)
+
+var (
+	messageFieldNamesLinter = NewParameterizedLinter(
+		"MESSAGE_FIELD_NAMES",
+		"Verifies that all message field names are of the configured style.",
+		[]*Parameter{messageFieldNamesStyleParameter},
+		checkMessageFieldNames,
+	)
+
+	messageFieldNamesStyleParameter = &Parameter{
+		Name:        "style",
+		Description: "The style of message field names.",
+		Default:     "lower_snake_case",
+		Values:      []string{"lower_snake_case", "lowerCamelCase", "lowercase"},
+	}
+
+	messageFieldNameStyleToStyle = map[string]*nameStyle{
+		"lower_snake_case": {
+			description: "lower_snake_case",
+			valid:       strs.IsLowerSnakeCase,
+			fix:         strs.ToLowerSnakeCase,
+		},
+		"lowerCamelCase": {
+			description: "lower CamelCase",
+			valid:       strs.IsLowerCamelCase,
+			fix:         strs.ToLowerCamelCase,
+		},
+		"lowercase": {
+			description: "lowercase",
+			valid:       strs.IsLowercase,
+			fix:         strings.ToLower,
+		},
+	}
+)
+
+type nameStyle struct {
+	description string
+	valid       func(string) bool
+	fix         func(string) string
+}
+
+func checkMessageFieldNames(add func(*text.Failure), dirPath string, descriptors []*proto.Proto, parameters map[string]string) error {
+	return runVisitor(messageFieldNamesVisitor{baseAddVisitor: newBaseAddVisitor(add), style: messageFieldNameStyleToStyle[parameters[messageFieldNamesStyleParameter.Name]]}, descriptors)
+}
+
+type messageFieldNamesVisitor struct {
+	baseAddVisitor
+
+	style *nameStyle
+}
+
+func (v messageFieldNamesVisitor) VisitMessage(message *proto.Message) {
+	for _, element := range message.Elements {
+		element.Accept(v)
+	}
+}
+
+func (v messageFieldNamesVisitor) VisitOneof(oneof *proto.Oneof) {
+	for _, element := range oneof.Elements {
+		element.Accept(v)
+	}
+}
+
+func (v messageFieldNamesVisitor) VisitNormalField(field *proto.NormalField) {
+	v.checkName(field.Position, field.Name)
+}
+
+func (v messageFieldNamesVisitor) VisitOneofField(field *proto.OneOfField) {
+	v.checkName(field.Position, field.Name)
+}
+
+func (v messageFieldNamesVisitor) VisitMapField(field *proto.MapField) {
+	v.checkName(field.Position, field.Name)
+}
+
+func (v messageFieldNamesVisitor) checkName(position scanner.Position, name string) {
+	if !v.style.valid(name) {
+		v.AddRenameFailuref(position, name, getFixedName(name, v.style.fix, v.style.valid), "Field name %q must be %s.", name, v.style.description)
+	}
+}
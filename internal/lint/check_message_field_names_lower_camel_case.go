@@ -54,18 +54,18 @@ func (v messageFieldNamesLowerCamelCaseVisitor) VisitOneof(oneof *proto.Oneof) {
 
 func (v messageFieldNamesLowerCamelCaseVisitor) VisitNormalField(field *proto.NormalField) {
 	if !strs.IsLowerCamelCase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lower CamelCase.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strs.ToLowerCamelCase, strs.IsLowerCamelCase), "Field name %q must be lower CamelCase.", field.Name)
 	}
 }
 
 func (v messageFieldNamesLowerCamelCaseVisitor) VisitOneofField(field *proto.OneOfField) {
 	if !strs.IsLowerCamelCase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lower CamelCase.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strs.ToLowerCamelCase, strs.IsLowerCamelCase), "Field name %q must be lower CamelCase.", field.Name)
 	}
 }
 
 func (v messageFieldNamesLowerCamelCaseVisitor) VisitMapField(field *proto.MapField) {
 	if !strs.IsLowerCamelCase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lower CamelCase.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strs.ToLowerCamelCase, strs.IsLowerCamelCase), "Field name %q must be lower CamelCase.", field.Name)
 	}
 }
@@ -54,18 +54,18 @@ func (v messageFieldNamesLowerSnakeCaseVisitor) VisitOneof(oneof *proto.Oneof) {
 
 func (v messageFieldNamesLowerSnakeCaseVisitor) VisitNormalField(field *proto.NormalField) {
 	if !strs.IsLowerSnakeCase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lower_snake_case.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strs.ToLowerSnakeCase, strs.IsLowerSnakeCase), "Field name %q must be lower_snake_case.", field.Name)
 	}
 }
 
 func (v messageFieldNamesLowerSnakeCaseVisitor) VisitOneofField(field *proto.OneOfField) {
 	if !strs.IsLowerSnakeCase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lower_snake_case.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strs.ToLowerSnakeCase, strs.IsLowerSnakeCase), "Field name %q must be lower_snake_case.", field.Name)
 	}
 }
 
 func (v messageFieldNamesLowerSnakeCaseVisitor) VisitMapField(field *proto.MapField) {
 	if !strs.IsLowerSnakeCase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lower_snake_case.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strs.ToLowerSnakeCase, strs.IsLowerSnakeCase), "Field name %q must be lower_snake_case.", field.Name)
 	}
 }
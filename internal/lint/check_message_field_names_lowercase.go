@@ -21,6 +21,8 @@
 package lint
 
 import (
+	"strings"
+
 	"github.com/emicklei/proto"
 	"github.com/uber/prototool/internal/strs"
 	"github.com/uber/prototool/internal/text"
@@ -54,18 +56,18 @@ func (v messageFieldNamesLowercaseVisitor) VisitOneof(oneof *proto.Oneof) {
 
 func (v messageFieldNamesLowercaseVisitor) VisitNormalField(field *proto.NormalField) {
 	if !strs.IsLowercase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lowercase.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strings.ToLower, strs.IsLowercase), "Field name %q must be lowercase.", field.Name)
 	}
 }
 
 func (v messageFieldNamesLowercaseVisitor) VisitOneofField(field *proto.OneOfField) {
 	if !strs.IsLowercase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lowercase.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strings.ToLower, strs.IsLowercase), "Field name %q must be lowercase.", field.Name)
 	}
 }
 
 func (v messageFieldNamesLowercaseVisitor) VisitMapField(field *proto.MapField) {
 	if !strs.IsLowercase(field.Name) {
-		v.AddFailuref(field.Position, "Field name %q must be lowercase.", field.Name)
+		v.AddRenameFailuref(field.Position, field.Name, getFixedName(field.Name, strings.ToLower, strs.IsLowercase), "Field name %q must be lowercase.", field.Name)
 	}
 }
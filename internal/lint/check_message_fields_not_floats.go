@@ -25,18 +25,34 @@ import (
 	"github.com/uber/prototool/internal/text"
 )
 
-var messageFieldsNotFloatsLinter = NewLinter(
-	"MESSAGE_FIELDS_NOT_FLOATS",
-	"Verifies that all message fields are not floats or doubles.",
-	checkMessageFieldsNotFloats,
+var (
+	messageFieldsNotFloatsLinter = NewParameterizedLinter(
+		"MESSAGE_FIELDS_NOT_FLOATS",
+		"Verifies that all message fields are not floats or doubles.",
+		[]*Parameter{messageFieldsNotFloatsAllowedTypesParameter},
+		checkMessageFieldsNotFloats,
+	)
+
+	messageFieldsNotFloatsAllowedTypesParameter = &Parameter{
+		Name:        "allowed_types",
+		Description: "The floating point types that are allowed.",
+		Values:      []string{"double", "float"},
+		List:        true,
+	}
 )
 
-func checkMessageFieldsNotFloats(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
-	return runVisitor(messageFieldsNotFloatsVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkMessageFieldsNotFloats(add func(*text.Failure), dirPath string, descriptors []*proto.Proto, parameters map[string]string) error {
+	allowedTypes := make(map[string]struct{})
+	for _, allowedType := range splitParameterList(parameters[messageFieldsNotFloatsAllowedTypesParameter.Name]) {
+		allowedTypes[allowedType] = struct{}{}
+	}
+	return runVisitor(messageFieldsNotFloatsVisitor{baseAddVisitor: newBaseAddVisitor(add), allowedTypes: allowedTypes}, descriptors)
 }
 
 type messageFieldsNotFloatsVisitor struct {
 	baseAddVisitor
+
+	allowedTypes map[string]struct{}
 }
 
 func (v messageFieldsNotFloatsVisitor) VisitMessage(message *proto.Message) {
@@ -64,6 +80,9 @@ func (v messageFieldsNotFloatsVisitor) VisitMapField(field *proto.MapField) {
 }
 
 func (v messageFieldsNotFloatsVisitor) checkNotFloat(field *proto.Field) {
+	if _, ok := v.allowedTypes[field.Type]; ok {
+		return
+	}
 	switch field.Type {
 	case "double", "float":
 		v.AddFailuref(field.Position, "Field %q is a float and floating point types are not allowed, consider using an int64 while representing your value in micros or nanos.", field.Name)
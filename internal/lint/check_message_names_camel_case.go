@@ -49,6 +49,6 @@ func (v messageNamesCamelCaseVisitor) VisitMessage(message *proto.Message) {
 		return
 	}
 	if !strs.IsCamelCase(message.Name) {
-		v.AddFailuref(message.Position, "Message name %q must be CamelCase.", message.Name)
+		v.AddRenameFailuref(message.Position, message.Name, getFixedName(message.Name, strs.ToUpperCamelCase, strs.IsCamelCase), "Message name %q must be CamelCase.", message.Name)
 	}
 }
@@ -49,6 +49,6 @@ func (v messageNamesCapitalizedVisitor) VisitMessage(message *proto.Message) {
 		return
 	}
 	if !strs.IsCapitalized(message.Name) {
-		v.AddFailuref(message.Position, "Message name %q must be capitalized.", message.Name)
+		v.AddRenameFailuref(message.Position, message.Name, getFixedName(message.Name, toCapitalized, strs.IsCapitalized), "Message name %q must be capitalized.", message.Name)
 	}
 }
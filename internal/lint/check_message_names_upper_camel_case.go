@@ -49,6 +49,6 @@ func (v messageNamesUpperCamelCaseVisitor) VisitMessage(message *proto.Message)
 		return
 	}
 	if !strs.IsUpperCamelCase(message.Name) {
-		v.AddFailuref(message.Position, "Message name %q must be upper CamelCase.", message.Name)
+		v.AddRenameFailuref(message.Position, message.Name, getFixedName(message.Name, strs.ToUpperCamelCase, strs.IsUpperCamelCase), "Message name %q must be upper CamelCase.", message.Name)
 	}
 }
@@ -21,25 +21,25 @@
 package lint
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/emicklei/proto"
 	"github.com/uber/prototool/internal/text"
 )
 
-var messagesHaveCommentsLinter = NewLinter(
+var messagesHaveCommentsLinter = NewParameterizedLinter(
 	"MESSAGES_HAVE_COMMENTS",
 	`Verifies that all non-extended messages have a comment of the form "// MessageName ...".`,
+	[]*Parameter{commentPrefixParameter},
 	checkMessagesHaveComments,
 )
 
-func checkMessagesHaveComments(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
-	return runVisitor(messagesHaveCommentsVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkMessagesHaveComments(add func(*text.Failure), dirPath string, descriptors []*proto.Proto, parameters map[string]string) error {
+	return runVisitor(messagesHaveCommentsVisitor{baseAddVisitor: newBaseAddVisitor(add), prefix: parameters[commentPrefixParameter.Name]}, descriptors)
 }
 
 type messagesHaveCommentsVisitor struct {
 	baseAddVisitor
+
+	prefix string
 }
 
 func (v messagesHaveCommentsVisitor) VisitMessage(message *proto.Message) {
@@ -50,7 +50,7 @@ func (v messagesHaveCommentsVisitor) VisitMessage(message *proto.Message) {
 	if message.IsExtend {
 		return
 	}
-	if message.Comment == nil || len(message.Comment.Lines) == 0 || !strings.HasPrefix(message.Comment.Lines[0], fmt.Sprintf(" %s ", message.Name)) {
-		v.AddFailuref(message.Position, `Message %q needs a comment of the form "// %s ..."`, message.Name, message.Name)
+	if !hasCommentPrefix(message.Comment, message.Name, v.prefix) {
+		v.AddFailuref(message.Position, `Message %q needs a comment of the form "// %s..."`, message.Name, getCommentPrefix(message.Name, v.prefix))
 	}
 }
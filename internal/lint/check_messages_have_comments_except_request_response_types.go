@@ -21,25 +21,26 @@
 package lint
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/emicklei/proto"
 	"github.com/uber/prototool/internal/text"
 )
 
-var messagesHaveCommentsExceptRequestResponseTypesLinter = NewLinter(
+var messagesHaveCommentsExceptRequestResponseTypesLinter = NewParameterizedLinter(
 	"MESSAGES_HAVE_COMMENTS_EXCEPT_REQUEST_RESPONSE_TYPES",
 	`Verifies that all non-extended messages except for request and response types have a comment of the form "// MessageName ...".`,
+	[]*Parameter{commentPrefixParameter},
 	checkMessagesHaveCommentsExceptRequestResponseTypes,
 )
 
-func checkMessagesHaveCommentsExceptRequestResponseTypes(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
-	return runVisitor(&messagesHaveCommentsExceptRequestResponseTypesVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkMessagesHaveCommentsExceptRequestResponseTypes(add func(*text.Failure), dirPath string, descriptors []*proto.Proto, parameters map[string]string) error {
+	return runVisitor(&messagesHaveCommentsExceptRequestResponseTypesVisitor{baseAddVisitor: newBaseAddVisitor(add), prefix: parameters[commentPrefixParameter.Name]}, descriptors)
 }
 
 type messagesHaveCommentsExceptRequestResponseTypesVisitor struct {
 	baseAddVisitor
+	prefix               string
 	messageNameToMessage map[string]*proto.Message
 	requestResponseTypes map[string]struct{}
 	nestedMessageNames   []string
@@ -90,8 +91,8 @@ func (v *messagesHaveCommentsExceptRequestResponseTypesVisitor) Finally() error
 	for messageName, message := range v.messageNameToMessage {
 		if !message.IsExtend {
 			if _, ok := v.requestResponseTypes[messageName]; !ok {
-				if message.Comment == nil || len(message.Comment.Lines) == 0 || !strings.HasPrefix(message.Comment.Lines[0], fmt.Sprintf(" %s ", message.Name)) {
-					v.AddFailuref(message.Position, `Message %q needs a comment of the form "// %s ..."`, message.Name, message.Name)
+				if !hasCommentPrefix(message.Comment, message.Name, v.prefix) {
+					v.AddFailuref(message.Position, `Message %q needs a comment of the form "// %s..."`, message.Name, getCommentPrefix(message.Name, v.prefix))
 				}
 			}
 		}
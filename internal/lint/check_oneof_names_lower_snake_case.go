@@ -48,6 +48,6 @@ func (v oneofNamesLowerSnakeCaseVisitor) VisitMessage(message *proto.Message) {
 
 func (v oneofNamesLowerSnakeCaseVisitor) VisitOneof(oneof *proto.Oneof) {
 	if !strs.IsLowerSnakeCase(oneof.Name) {
-		v.AddFailuref(oneof.Position, "Oneof name %q must be lower_snake_case.", oneof.Name)
+		v.AddRenameFailuref(oneof.Position, oneof.Name, getFixedName(oneof.Name, strs.ToLowerSnakeCase, strs.IsLowerSnakeCase), "Oneof name %q must be lower_snake_case.", oneof.Name)
 	}
 }
@@ -0,0 +1,124 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/uber/prototool/internal/location"
+	"github.com/uber/prototool/internal/text"
+)
+
+var (
+	packageImportRulesLinter = NewParameterizedDescriptorLinter(
+		"PACKAGE_IMPORT_RULES",
+		"Verifies that no files import files of packages that their package is forbidden to import.",
+		[]*Parameter{packageImportRulesForbiddenParameter},
+		checkPackageImportRules,
+	)
+
+	packageImportRulesForbiddenParameter = &Parameter{
+		Name:        "forbidden",
+		Description: `The forbidden imports between packages, such as "foo.common.** -> foo.service.**", which forbids the files of foo.common and its sub-packages from importing the files of foo.service and its sub-packages. Patterns are matched per package component, where * matches one component and ** matches any number of components.`,
+		List:        true,
+		Validate:    validatePackageImportRules,
+	}
+)
+
+// packageImportRule is a forbidden import between packages.
+type packageImportRule struct {
+	// rule is the rule as configured.
+	rule string
+	// from is the pattern of the importing packages.
+	from string
+	// to is the pattern of the imported packages.
+	to string
+}
+
+func checkPackageImportRules(add func(*text.Failure), dirPath string, fileDescriptors []*FileDescriptor, parameters map[string]string) error {
+	rules, err := getPackageImportRules(parameters[packageImportRulesForbiddenParameter.Name])
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 || len(fileDescriptors) == 0 {
+		return nil
+	}
+	nameToFile := getNameToFile(fileDescriptors[0].FileDescriptorSet)
+	for _, fileDescriptor := range fileDescriptors {
+		pkg := fileDescriptor.GetPackage()
+		if pkg == "" {
+			continue
+		}
+		for i, dependency := range fileDescriptor.GetDependency() {
+			importedPackage := nameToFile[dependency].GetPackage()
+			if importedPackage == "" || importedPackage == pkg {
+				continue
+			}
+			for _, rule := range rules {
+				fromMatches, err := matchGlob(rule.from, pkg, ".")
+				if err != nil {
+					return err
+				}
+				toMatches, err := matchGlob(rule.to, importedPackage, ".")
+				if err != nil {
+					return err
+				}
+				if fromMatches && toMatches {
+					add(text.NewFailuref(fileDescriptor.Position(location.Path{}.Scope(location.Dependency, i)), "", "Package %q may not import %q of package %q, which is forbidden by the rule %q.", pkg, dependency, importedPackage, rule.rule))
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validatePackageImportRules returns an error if the value of the
+// forbidden parameter can not be parsed.
+func validatePackageImportRules(value string) error {
+	_, err := getPackageImportRules(value)
+	return err
+}
+
+// getPackageImportRules parses the value of the forbidden parameter.
+func getPackageImportRules(value string) ([]*packageImportRule, error) {
+	var rules []*packageImportRule
+	for _, rule := range splitParameterList(value) {
+		split := strings.Split(rule, "->")
+		if len(split) != 2 || strings.TrimSpace(split[0]) == "" || strings.TrimSpace(split[1]) == "" {
+			return nil, fmt.Errorf("invalid rule %q, rules must be of the form \"foo.common.** -> foo.service.**\"", rule)
+		}
+		packageImportRule := &packageImportRule{
+			rule: rule,
+			from: strings.TrimSpace(split[0]),
+			to:   strings.TrimSpace(split[1]),
+		}
+		for _, pattern := range []string{packageImportRule.from, packageImportRule.to} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in rule %q: %v", pattern, rule, err)
+			}
+		}
+		rules = append(rules, packageImportRule)
+	}
+	return rules, nil
+}
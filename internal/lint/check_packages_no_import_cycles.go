@@ -0,0 +1,88 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"strings"
+
+	"github.com/uber/prototool/internal/location"
+	"github.com/uber/prototool/internal/text"
+)
+
+var packagesNoImportCyclesLinter = NewDescriptorLinter(
+	"PACKAGES_NO_IMPORT_CYCLES",
+	"Verifies that the imports between the files of different packages do not form a cycle between the packages.",
+	checkPackagesNoImportCycles,
+)
+
+func checkPackagesNoImportCycles(add func(*text.Failure), dirPath string, fileDescriptors []*FileDescriptor) error {
+	if len(fileDescriptors) == 0 {
+		return nil
+	}
+	// the graph is built from all files of the ProtoSet, so that
+	// cycles through other directories are found
+	fileDescriptorSet := fileDescriptors[0].FileDescriptorSet
+	packageToImportedPackages := getPackageToImportedPackages(fileDescriptorSet)
+	nameToFile := getNameToFile(fileDescriptorSet)
+	for _, fileDescriptor := range fileDescriptors {
+		pkg := fileDescriptor.GetPackage()
+		if pkg == "" {
+			continue
+		}
+		for i, dependency := range fileDescriptor.GetDependency() {
+			importedPackage := nameToFile[dependency].GetPackage()
+			if importedPackage == "" || importedPackage == pkg {
+				continue
+			}
+			packagePath := getPackageImportPath(packageToImportedPackages, importedPackage, pkg)
+			if packagePath == nil {
+				continue
+			}
+			add(text.NewFailuref(fileDescriptor.Position(location.Path{}.Scope(location.Dependency, i)), "", "Import %q creates the package import cycle %s.", dependency, strings.Join(append([]string{pkg}, packagePath...), " -> ")))
+		}
+	}
+	return nil
+}
+
+// getPackageImportPath returns the shortest path of imports from one
+// package to another, including both packages, or nil if there is none.
+func getPackageImportPath(packageToImportedPackages map[string][]string, from string, to string) []string {
+	packageToPrevious := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if pkg == to {
+			var packagePath []string
+			for ; pkg != ""; pkg = packageToPrevious[pkg] {
+				packagePath = append([]string{pkg}, packagePath...)
+			}
+			return packagePath
+		}
+		for _, importedPackage := range packageToImportedPackages[pkg] {
+			if _, ok := packageToPrevious[importedPackage]; !ok {
+				packageToPrevious[importedPackage] = pkg
+				queue = append(queue, importedPackage)
+			}
+		}
+	}
+	return nil
+}
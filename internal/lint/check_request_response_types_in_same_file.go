@@ -22,101 +22,43 @@ package lint
 
 import (
 	"strings"
-	"text/scanner"
 
-	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/location"
 	"github.com/uber/prototool/internal/text"
 	"github.com/uber/prototool/internal/wkt"
 )
 
-var requestResponseTypesInSameFileLinter = NewLinter(
+var requestResponseTypesInSameFileLinter = NewDescriptorLinter(
 	"REQUEST_RESPONSE_TYPES_IN_SAME_FILE",
 	"Verifies that all request and response types are in the same file as their corresponding service and are not nested messages.",
 	checkRequestResponseTypesInSameFile,
 )
 
-func checkRequestResponseTypesInSameFile(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
-	return runVisitor(&requestResponseTypesInSameFileVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
-}
-
-type requestResponseTypesInSameFileVisitor struct {
-	baseAddVisitor
-	messageTypes       map[string]struct{}
-	rpcs               []*requestResponseTypesInSameFileVisitorRPCMapKey
-	nestedMessageNames []string
-}
-
-func (v *requestResponseTypesInSameFileVisitor) OnStart(*proto.Proto) error {
-	v.messageTypes = nil
-	v.rpcs = nil
-	v.nestedMessageNames = nil
-	return nil
-}
-
-func (v *requestResponseTypesInSameFileVisitor) VisitMessage(message *proto.Message) {
-	v.nestedMessageNames = append(v.nestedMessageNames, message.Name)
-	for _, child := range message.Elements {
-		child.Accept(v)
-	}
-	v.nestedMessageNames = v.nestedMessageNames[:len(v.nestedMessageNames)-1]
-
-	if v.messageTypes == nil {
-		v.messageTypes = make(map[string]struct{})
-	}
-	if len(v.nestedMessageNames) > 0 {
-		v.messageTypes[strings.Join(v.nestedMessageNames, ".")+"."+message.Name] = struct{}{}
-	} else {
-		v.messageTypes[message.Name] = struct{}{}
-	}
-}
-
-func (v *requestResponseTypesInSameFileVisitor) VisitService(service *proto.Service) {
-	for _, child := range service.Elements {
-		child.Accept(v)
-	}
-}
-
-func (v *requestResponseTypesInSameFileVisitor) VisitRPC(rpc *proto.RPC) {
-	v.rpcs = append(v.rpcs, &requestResponseTypesInSameFileVisitorRPCMapKey{
-		Position:     rpc.Position,
-		RequestType:  rpcTypeFilter(rpc.RequestType),
-		ResponseType: rpcTypeFilter(rpc.ReturnsType),
-	})
-}
-
-func (v *requestResponseTypesInSameFileVisitor) Finally() error {
-	if v.messageTypes == nil {
-		v.messageTypes = make(map[string]struct{})
-	}
-	for _, rpc := range v.rpcs {
-		if rpc.RequestType != "" {
-			if _, ok := v.messageTypes[rpc.RequestType]; !ok {
-				v.AddFailuref(rpc.Position, "Request type %q should be defined in the same file as the corresponding service.", rpc.RequestType)
-			} else if strings.ContainsRune(rpc.RequestType, '.') {
-				v.AddFailuref(rpc.Position, "Request type %q is a nested message and only top-level messages should be request types.", rpc.RequestType)
-			}
-		}
-		if rpc.ResponseType != "" {
-			if _, ok := v.messageTypes[rpc.ResponseType]; !ok {
-				v.AddFailuref(rpc.Position, "Response type %q should be defined in the same file as the corresponding service.", rpc.ResponseType)
-			} else if strings.ContainsRune(rpc.ResponseType, '.') {
-				v.AddFailuref(rpc.Position, "Response type %q is a nested message and only top-level messages should be response types.", rpc.ResponseType)
+func checkRequestResponseTypesInSameFile(add func(*text.Failure), dirPath string, fileDescriptors []*FileDescriptor) error {
+	for _, fileDescriptor := range fileDescriptors {
+		typeNameToMessage := getTypeNameToMessage(fileDescriptor.FileDescriptorSet)
+		for i, service := range fileDescriptor.GetService() {
+			for j, method := range service.GetMethod() {
+				position := fileDescriptor.Position(location.Path{}.Scope(location.Service, i).Scope(location.Method, j))
+				for _, rpcType := range []struct {
+					kind     string
+					typeName string
+				}{
+					{kind: "Request", typeName: method.GetInputType()},
+					{kind: "Response", typeName: method.GetOutputType()},
+				} {
+					if strings.HasPrefix(rpcType.typeName, "."+wkt.PACKAGE+".") {
+						continue
+					}
+					message, ok := typeNameToMessage[rpcType.typeName]
+					if !ok || message.file != fileDescriptor.FileDescriptorProto {
+						add(text.NewFailuref(position, "", "%s type %q should be defined in the same file as the corresponding service.", rpcType.kind, getRelativeTypeName(fileDescriptor, rpcType.typeName)))
+					} else if message.nested {
+						add(text.NewFailuref(position, "", "%s type %q is a nested message and only top-level messages should be %s types.", rpcType.kind, getRelativeTypeName(fileDescriptor, rpcType.typeName), strings.ToLower(rpcType.kind)))
+					}
+				}
 			}
 		}
 	}
 	return nil
 }
-
-type requestResponseTypesInSameFileVisitorRPCMapKey struct {
-	Position     scanner.Position
-	RequestType  string
-	ResponseType string
-}
-
-func rpcTypeFilter(typed string) string {
-	if strings.HasPrefix(typed, wkt.PACKAGE) {
-		return ""
-	} else {
-		return typed
-	}
-}
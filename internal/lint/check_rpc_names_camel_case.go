@@ -48,6 +48,6 @@ func (v rpcNamesCamelCaseVisitor) VisitService(service *proto.Service) {
 
 func (v rpcNamesCamelCaseVisitor) VisitRPC(rpc *proto.RPC) {
 	if !strs.IsCamelCase(rpc.Name) {
-		v.AddFailuref(rpc.Position, "RPC name %q must be CamelCase.", rpc.Name)
+		v.AddRenameFailuref(rpc.Position, rpc.Name, getFixedName(rpc.Name, toCamelCase, strs.IsCamelCase), "RPC name %q must be CamelCase.", rpc.Name)
 	}
 }
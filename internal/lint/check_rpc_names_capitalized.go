@@ -48,6 +48,6 @@ func (v rpcNamesCapitalizedVisitor) VisitService(service *proto.Service) {
 
 func (v rpcNamesCapitalizedVisitor) VisitRPC(rpc *proto.RPC) {
 	if !strs.IsCapitalized(rpc.Name) {
-		v.AddFailuref(rpc.Position, "RPC name %q must be capitalized.", rpc.Name)
+		v.AddRenameFailuref(rpc.Position, rpc.Name, getFixedName(rpc.Name, toCapitalized, strs.IsCapitalized), "RPC name %q must be capitalized.", rpc.Name)
 	}
 }
@@ -48,6 +48,6 @@ func (v rpcNamesLowerCamelCaseVisitor) VisitService(service *proto.Service) {
 
 func (v rpcNamesLowerCamelCaseVisitor) VisitRPC(rpc *proto.RPC) {
 	if !strs.IsLowerCamelCase(rpc.Name) {
-		v.AddFailuref(rpc.Position, "RPC name %q must be lower CamelCase.", rpc.Name)
+		v.AddRenameFailuref(rpc.Position, rpc.Name, getFixedName(rpc.Name, strs.ToLowerCamelCase, strs.IsLowerCamelCase), "RPC name %q must be lower CamelCase.", rpc.Name)
 	}
 }
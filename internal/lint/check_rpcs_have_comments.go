@@ -21,25 +21,25 @@
 package lint
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/emicklei/proto"
 	"github.com/uber/prototool/internal/text"
 )
 
-var rpcsHaveCommentsLinter = NewLinter(
+var rpcsHaveCommentsLinter = NewParameterizedLinter(
 	"RPCS_HAVE_COMMENTS",
 	`Verifies that all rpcs have a comment of the form "// RPCName ...".`,
+	[]*Parameter{commentPrefixParameter},
 	checkRPCsHaveComments,
 )
 
-func checkRPCsHaveComments(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
-	return runVisitor(rpcsHaveCommentsVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkRPCsHaveComments(add func(*text.Failure), dirPath string, descriptors []*proto.Proto, parameters map[string]string) error {
+	return runVisitor(rpcsHaveCommentsVisitor{baseAddVisitor: newBaseAddVisitor(add), prefix: parameters[commentPrefixParameter.Name]}, descriptors)
 }
 
 type rpcsHaveCommentsVisitor struct {
 	baseAddVisitor
+
+	prefix string
 }
 
 func (v rpcsHaveCommentsVisitor) VisitService(service *proto.Service) {
@@ -49,7 +49,7 @@ func (v rpcsHaveCommentsVisitor) VisitService(service *proto.Service) {
 }
 
 func (v rpcsHaveCommentsVisitor) VisitRPC(rpc *proto.RPC) {
-	if rpc.Comment == nil || len(rpc.Comment.Lines) == 0 || !strings.HasPrefix(rpc.Comment.Lines[0], fmt.Sprintf(" %s ", rpc.Name)) {
-		v.AddFailuref(rpc.Position, `RPC %q needs a comment of the form "// %s ..."`, rpc.Name, rpc.Name)
+	if !hasCommentPrefix(rpc.Comment, rpc.Name, v.prefix) {
+		v.AddFailuref(rpc.Position, `RPC %q needs a comment of the form "// %s..."`, rpc.Name, getCommentPrefix(rpc.Name, v.prefix))
 	}
 }
@@ -42,6 +42,6 @@ type serviceNamesCamelCaseVisitor struct {
 
 func (v serviceNamesCamelCaseVisitor) VisitService(service *proto.Service) {
 	if !strs.IsCamelCase(service.Name) {
-		v.AddFailuref(service.Position, "Service name %q must be CamelCase.", service.Name)
+		v.AddRenameFailuref(service.Position, service.Name, getFixedName(service.Name, strs.ToUpperCamelCase, strs.IsCamelCase), "Service name %q must be CamelCase.", service.Name)
 	}
 }
@@ -42,6 +42,6 @@ type serviceNamesCapitalizedVisitor struct {
 
 func (v serviceNamesCapitalizedVisitor) VisitService(service *proto.Service) {
 	if !strs.IsCapitalized(service.Name) {
-		v.AddFailuref(service.Position, "Service name %q must be capitalized.", service.Name)
+		v.AddRenameFailuref(service.Position, service.Name, getFixedName(service.Name, toCapitalized, strs.IsCapitalized), "Service name %q must be capitalized.", service.Name)
 	}
 }
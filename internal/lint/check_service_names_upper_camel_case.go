@@ -42,6 +42,6 @@ type serviceNamesUpperCamelCaseVisitor struct {
 
 func (v serviceNamesUpperCamelCaseVisitor) VisitService(service *proto.Service) {
 	if !strs.IsUpperCamelCase(service.Name) {
-		v.AddFailuref(service.Position, "Service name %q must be upper CamelCase.", service.Name)
+		v.AddRenameFailuref(service.Position, service.Name, getFixedName(service.Name, strs.ToUpperCamelCase, strs.IsUpperCamelCase), "Service name %q must be upper CamelCase.", service.Name)
 	}
 }
@@ -21,29 +21,29 @@
 package lint
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/emicklei/proto"
 	"github.com/uber/prototool/internal/text"
 )
 
-var servicesHaveCommentsLinter = NewLinter(
+var servicesHaveCommentsLinter = NewParameterizedLinter(
 	"SERVICES_HAVE_COMMENTS",
 	`Verifies that all services have a comment of the form "// ServiceName ...".`,
+	[]*Parameter{commentPrefixParameter},
 	checkServicesHaveComments,
 )
 
-func checkServicesHaveComments(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
-	return runVisitor(servicesHaveCommentsVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
+func checkServicesHaveComments(add func(*text.Failure), dirPath string, descriptors []*proto.Proto, parameters map[string]string) error {
+	return runVisitor(servicesHaveCommentsVisitor{baseAddVisitor: newBaseAddVisitor(add), prefix: parameters[commentPrefixParameter.Name]}, descriptors)
 }
 
 type servicesHaveCommentsVisitor struct {
 	baseAddVisitor
+
+	prefix string
 }
 
 func (v servicesHaveCommentsVisitor) VisitService(service *proto.Service) {
-	if service.Comment == nil || len(service.Comment.Lines) == 0 || !strings.HasPrefix(service.Comment.Lines[0], fmt.Sprintf(" %s ", service.Name)) {
-		v.AddFailuref(service.Position, `Service %q needs a comment of the form "// %s ..."`, service.Name, service.Name)
+	if !hasCommentPrefix(service.Comment, service.Name, v.prefix) {
+		v.AddFailuref(service.Position, `Service %q needs a comment of the form "// %s..."`, service.Name, getCommentPrefix(service.Name, v.prefix))
 	}
 }
@@ -0,0 +1,220 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/location"
+	"github.com/uber/prototool/internal/text"
+)
+
+var unusedImportsLinter = NewDescriptorLinter(
+	"UNUSED_IMPORTS",
+	`Verifies that all imports are used by a type, extension, or custom option, which protoc does not check if "protoc.allow_unused_imports" is set.`,
+	checkUnusedImports,
+)
+
+// extensionKey identifies an extension by the type name of the message
+// it extends and its field number.
+type extensionKey struct {
+	extendee string
+	number   int32
+}
+
+func checkUnusedImports(add func(*text.Failure), dirPath string, fileDescriptors []*FileDescriptor) error {
+	if len(fileDescriptors) == 0 {
+		return nil
+	}
+	fileDescriptorSet := fileDescriptors[0].FileDescriptorSet
+	typeNameToType := getTypeNameToType(fileDescriptorSet)
+	nameToFile := getNameToFile(fileDescriptorSet)
+	extensionKeyToFileName := make(map[extensionKey]string)
+	for _, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+		for _, extension := range getExtensions(fileDescriptorProto) {
+			extensionKeyToFileName[extensionKey{extendee: extension.GetExtendee(), number: extension.GetNumber()}] = fileDescriptorProto.GetName()
+		}
+	}
+	for _, fileDescriptor := range fileDescriptors {
+		references := &fileReferences{}
+		if err := references.addFile(fileDescriptor.FileDescriptorProto); err != nil {
+			return err
+		}
+		usedFileNames := make(map[string]struct{})
+		for _, typeName := range references.typeNames {
+			if referencedType, ok := typeNameToType[typeName]; ok {
+				usedFileNames[referencedType.file.GetName()] = struct{}{}
+			}
+		}
+		for _, option := range references.options {
+			if fileName, ok := extensionKeyToFileName[option]; ok {
+				usedFileNames[fileName] = struct{}{}
+			}
+		}
+		// public and weak imports are not required to be used
+		notChecked := make(map[int32]struct{})
+		for _, index := range fileDescriptor.GetPublicDependency() {
+			notChecked[index] = struct{}{}
+		}
+		for _, index := range fileDescriptor.GetWeakDependency() {
+			notChecked[index] = struct{}{}
+		}
+		for i, dependency := range fileDescriptor.GetDependency() {
+			if _, ok := notChecked[int32(i)]; ok {
+				continue
+			}
+			if !isFileUsed(dependency, usedFileNames, nameToFile, make(map[string]struct{})) {
+				add(text.NewFailuref(fileDescriptor.Position(location.Path{}.Scope(location.Dependency, i)), "", "Import %q is not used.", dependency))
+			}
+		}
+	}
+	return nil
+}
+
+// isFileUsed returns true if the file or any of the files that it
+// publicly imports is used.
+func isFileUsed(fileName string, usedFileNames map[string]struct{}, nameToFile map[string]*descriptor.FileDescriptorProto, seen map[string]struct{}) bool {
+	if _, ok := seen[fileName]; ok {
+		return false
+	}
+	seen[fileName] = struct{}{}
+	if _, ok := usedFileNames[fileName]; ok {
+		return true
+	}
+	fileDescriptorProto, ok := nameToFile[fileName]
+	if !ok {
+		return false
+	}
+	for _, index := range fileDescriptorProto.GetPublicDependency() {
+		if isFileUsed(fileDescriptorProto.GetDependency()[index], usedFileNames, nameToFile, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileReferences are the types and custom options that a file uses.
+type fileReferences struct {
+	// typeNames are the type names of field types, extendees, and
+	// request and response types.
+	typeNames []string
+	// options are the options that are set, which include the
+	// custom options.
+	options []extensionKey
+}
+
+func (r *fileReferences) addFile(fileDescriptorProto *descriptor.FileDescriptorProto) error {
+	if err := r.addOptions(".google.protobuf.FileOptions", fileDescriptorProto.GetOptions()); err != nil {
+		return err
+	}
+	for _, message := range fileDescriptorProto.GetMessageType() {
+		if err := r.addMessage(message); err != nil {
+			return err
+		}
+	}
+	for _, enum := range fileDescriptorProto.GetEnumType() {
+		if err := r.addEnum(enum); err != nil {
+			return err
+		}
+	}
+	for _, extension := range fileDescriptorProto.GetExtension() {
+		if err := r.addField(extension); err != nil {
+			return err
+		}
+	}
+	for _, service := range fileDescriptorProto.GetService() {
+		if err := r.addOptions(".google.protobuf.ServiceOptions", service.GetOptions()); err != nil {
+			return err
+		}
+		for _, method := range service.GetMethod() {
+			r.typeNames = append(r.typeNames, method.GetInputType(), method.GetOutputType())
+			if err := r.addOptions(".google.protobuf.MethodOptions", method.GetOptions()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *fileReferences) addMessage(message *descriptor.DescriptorProto) error {
+	if err := r.addOptions(".google.protobuf.MessageOptions", message.GetOptions()); err != nil {
+		return err
+	}
+	for _, field := range message.GetField() {
+		if err := r.addField(field); err != nil {
+			return err
+		}
+	}
+	for _, extension := range message.GetExtension() {
+		if err := r.addField(extension); err != nil {
+			return err
+		}
+	}
+	for _, oneof := range message.GetOneofDecl() {
+		if err := r.addOptions(".google.protobuf.OneofOptions", oneof.GetOptions()); err != nil {
+			return err
+		}
+	}
+	for _, nestedMessage := range message.GetNestedType() {
+		if err := r.addMessage(nestedMessage); err != nil {
+			return err
+		}
+	}
+	for _, enum := range message.GetEnumType() {
+		if err := r.addEnum(enum); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fileReferences) addField(field *descriptor.FieldDescriptorProto) error {
+	if field.GetTypeName() != "" {
+		r.typeNames = append(r.typeNames, field.GetTypeName())
+	}
+	if field.GetExtendee() != "" {
+		r.typeNames = append(r.typeNames, field.GetExtendee())
+	}
+	return r.addOptions(".google.protobuf.FieldOptions", field.GetOptions())
+}
+
+func (r *fileReferences) addEnum(enum *descriptor.EnumDescriptorProto) error {
+	if err := r.addOptions(".google.protobuf.EnumOptions", enum.GetOptions()); err != nil {
+		return err
+	}
+	for _, value := range enum.GetValue() {
+		if err := r.addOptions(".google.protobuf.EnumValueOptions", value.GetOptions()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fileReferences) addOptions(extendee string, options proto.Message) error {
+	numbers, err := getOptionNumbers(options)
+	if err != nil {
+		return err
+	}
+	for _, number := range numbers {
+		r.options = append(r.options, extensionKey{extendee: extendee, number: number})
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"strings"
+
+	"github.com/uber/prototool/internal/text"
+)
+
+var (
+	unusedTypesLinter = NewParameterizedDescriptorLinter(
+		"UNUSED_TYPES",
+		"Verifies that all messages and enums are used by an RPC or extension, directly or through the fields of other messages, or are configured as roots.",
+		[]*Parameter{unusedTypesRootsParameter},
+		checkUnusedTypes,
+	)
+
+	unusedTypesRootsParameter = &Parameter{
+		Name:        "roots",
+		Description: "The fully-qualified names of the messages and enums that are used outside of the files, such as foo.v1.Event, which are used in addition to the request and response types of RPCs.",
+		List:        true,
+	}
+)
+
+func checkUnusedTypes(add func(*text.Failure), dirPath string, fileDescriptors []*FileDescriptor, parameters map[string]string) error {
+	if len(fileDescriptors) == 0 {
+		return nil
+	}
+	// all files share the FileDescriptorSet of the ProtoSet, so that
+	// types can be used from other directories
+	fileDescriptorSet := fileDescriptors[0].FileDescriptorSet
+	typeNameToType := getTypeNameToType(fileDescriptorSet)
+	rootTypeNames := getRootTypeNames(fileDescriptorSet)
+	for _, root := range splitParameterList(parameters[unusedTypesRootsParameter.Name]) {
+		rootTypeNames = append(rootTypeNames, "."+strings.TrimPrefix(root, "."))
+	}
+	usedTypeNames := getUsedTypeNames(typeNameToType, rootTypeNames)
+	for _, fileDescriptor := range fileDescriptors {
+		for _, typeName := range getSortedTypeNamesInFile(typeNameToType, fileDescriptor.FileDescriptorProto) {
+			if _, ok := usedTypeNames[typeName]; ok {
+				continue
+			}
+			definedType := typeNameToType[typeName]
+			if definedType.isMapEntry {
+				continue
+			}
+			// only the outermost unused type is reported
+			parentTypeName := typeName[:strings.LastIndex(typeName, ".")]
+			if _, ok := typeNameToType[parentTypeName]; ok {
+				if _, ok := usedTypeNames[parentTypeName]; !ok {
+					continue
+				}
+			}
+			kind := "Message"
+			if definedType.isEnum {
+				kind = "Enum"
+			}
+			add(text.NewFailuref(fileDescriptor.Position(definedType.path), "", "%s %q is not used by any RPC, extension, or used message, and is not configured as a root.", kind, getRelativeTypeName(fileDescriptor, typeName)))
+		}
+	}
+	return nil
+}
@@ -23,34 +23,28 @@ package lint
 import (
 	"strings"
 
-	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/location"
 	"github.com/uber/prototool/internal/text"
 	"github.com/uber/prototool/internal/wkt"
 )
 
-// TODO: This will not detect things like "timestamp.proto" or "protobuf/timestamp.proto"
-// A more robust solution might be needed
-
 var (
-	wktDirectlyImportedLinter = NewLinter(
+	wktDirectlyImportedLinter = NewDescriptorLinter(
 		"WKT_DIRECTLY_IMPORTED",
 		`Verifies that the Well-Known Types are directly imported using "google/protobuf/" as the base of the import.`,
 		checkWKTDirectlyImported,
 	)
 )
 
-func checkWKTDirectlyImported(add func(*text.Failure), dirPath string, descriptors []*proto.Proto) error {
-	return runVisitor(&wktDirectlyImportedVisitor{baseAddVisitor: newBaseAddVisitor(add)}, descriptors)
-}
-
-type wktDirectlyImportedVisitor struct {
-	baseAddVisitor
-}
-
-func (v wktDirectlyImportedVisitor) VisitImport(element *proto.Import) {
-	for wktFilename := range wkt.Filenames {
-		if strings.HasSuffix(element.Filename, wktFilename) && element.Filename != wktFilename {
-			v.AddFailuref(element.Position, "Import %q is a Well-Known Type import but should be imported using google/protobuf as the base.", element.Filename)
+func checkWKTDirectlyImported(add func(*text.Failure), dirPath string, fileDescriptors []*FileDescriptor) error {
+	for _, fileDescriptor := range fileDescriptors {
+		for i, dependency := range fileDescriptor.GetDependency() {
+			for wktFilename := range wkt.Filenames {
+				if strings.HasSuffix(dependency, wktFilename) && dependency != wktFilename {
+					add(text.NewFailuref(fileDescriptor.Position(location.Path{}.Scope(location.Dependency, i)), "", "Import %q is a Well-Known Type import but should be imported using google/protobuf as the base.", dependency))
+				}
+			}
 		}
 	}
+	return nil
 }
@@ -0,0 +1,52 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"strings"
+
+	"github.com/emicklei/proto"
+)
+
+// commentPrefixParameter is the parameter of the linters that verify
+// that elements have comments for the prefix of the comments.
+var commentPrefixParameter = &Parameter{
+	Name:        "prefix",
+	Description: "The prefix of comments, where {name} is replaced by the name of the element. If empty, any comment is allowed.",
+	Default:     "{name} ",
+}
+
+// hasCommentPrefix returns true if the comment starts with the prefix
+// for the element with the given name.
+func hasCommentPrefix(comment *proto.Comment, name string, prefix string) bool {
+	if comment == nil || len(comment.Lines) == 0 {
+		return false
+	}
+	if prefix == "" {
+		return true
+	}
+	return strings.HasPrefix(comment.Lines[0], " "+getCommentPrefix(name, prefix))
+}
+
+// getCommentPrefix returns the prefix for the element with the given name.
+func getCommentPrefix(name string, prefix string) string {
+	return strings.Replace(prefix, "{name}", name, -1)
+}
@@ -0,0 +1,2056 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Explanation is the longer documentation of a Linter, printed by
+// "prototool lint explain".
+type Explanation struct {
+	// Rationale is why the Linter exists and how to fix its failures.
+	Rationale string
+	// Bad is an example that fails the Linter, from file path
+	// relative to the root of the example to file data.
+	Bad map[string]string
+	// Good is an example that passes the Linter, from file path
+	// relative to the root of the example to file data.
+	Good map[string]string
+	// Previous is the state of the files before the change that Bad and
+	// Good make, for Linters that compare against a previous state such
+	// as DELETED_FIELDS_RESERVED. This is nil for all other Linters.
+	Previous map[string]string
+	// Parameters are the parameter values that Bad and Good are linted
+	// with, for ParameterizedLinters that do nothing with their default
+	// values such as PACKAGE_IMPORT_RULES. This is nil for all other Linters.
+	Parameters map[string]string
+}
+
+// GetExplanation returns the Explanation for the Linter with the given ID.
+func GetExplanation(id string) (*Explanation, error) {
+	explanation, ok := idToExplanation[strings.ToUpper(id)]
+	if !ok {
+		return nil, fmt.Errorf("no explanation for lint id %s", strings.ToUpper(id))
+	}
+	return explanation, nil
+}
+
+// fooProto returns an example with the single file foo.proto.
+func fooProto(data string) map[string]string {
+	return map[string]string{"foo.proto": data}
+}
+
+// idToExplanation is the map from Linter ID to Explanation.
+//
+// The examples are verified by the tests in internal/cmd, every Linter
+// in AllLinters must have an Explanation.
+var idToExplanation = map[string]*Explanation{
+	"AIP_CREATE_REQUESTS": {
+		Rationale: `AIP-133 defines the shape of Create methods so that clients of all resource-oriented APIs look the same. The request is named after the RPC and has a field named after the resource that contains the resource to create, and the response is the created resource, or a google.longrunning.Operation if the creation is long-running.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+  string title = 2;
+}
+
+message CreateBookRequest {
+  string parent = 1;
+  string title = 2;
+}
+
+service Library {
+  rpc CreateBook(CreateBookRequest) returns (Book);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+  string title = 2;
+}
+
+message CreateBookRequest {
+  string parent = 1;
+  Book book = 2;
+}
+
+service Library {
+  rpc CreateBook(CreateBookRequest) returns (Book);
+}
+`),
+	},
+	"AIP_DELETE_REQUESTS": {
+		Rationale: `AIP-135 defines the shape of Delete methods so that clients of all resource-oriented APIs look the same. The request is named after the RPC and has a string field "name" with the name of the resource to delete, and the response is google.protobuf.Empty, the deleted resource for soft deletes, or a google.longrunning.Operation if the deletion is long-running.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message DeleteBookRequest {
+  string name = 1;
+}
+
+message DeleteBookResponse {}
+
+service Library {
+  rpc DeleteBook(DeleteBookRequest) returns (DeleteBookResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+import "google/protobuf/empty.proto";
+
+message DeleteBookRequest {
+  string name = 1;
+}
+
+service Library {
+  rpc DeleteBook(DeleteBookRequest) returns (google.protobuf.Empty);
+}
+`),
+	},
+	"AIP_GET_REQUESTS": {
+		Rationale: `AIP-131 defines the shape of Get methods so that clients of all resource-oriented APIs look the same. The request is named after the RPC and has a string field "name" with the name of the resource, and the response is the resource itself rather than a message that wraps it.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+}
+
+message GetBookRequest {
+  string book_id = 1;
+}
+
+service Library {
+  rpc GetBook(GetBookRequest) returns (Book);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+}
+
+message GetBookRequest {
+  string name = 1;
+}
+
+service Library {
+  rpc GetBook(GetBookRequest) returns (Book);
+}
+`),
+	},
+	"AIP_LIST_PAGINATION": {
+		Rationale: `AIP-158 requires List methods to be paginated from the start, as adding pagination later is a breaking change for clients that expect all results in one response. The request has the fields "int32 page_size" and "string page_token", and the response has the field "string next_page_token", which is empty on the last page.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+}
+
+message ListBooksRequest {
+  string parent = 1;
+  int32 page_size = 2;
+  int32 offset = 3;
+}
+
+message ListBooksResponse {
+  repeated Book books = 1;
+}
+
+service Library {
+  rpc ListBooks(ListBooksRequest) returns (ListBooksResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+}
+
+message ListBooksRequest {
+  string parent = 1;
+  int32 page_size = 2;
+  string page_token = 3;
+}
+
+message ListBooksResponse {
+  repeated Book books = 1;
+  string next_page_token = 2;
+}
+
+service Library {
+  rpc ListBooks(ListBooksRequest) returns (ListBooksResponse);
+}
+`),
+	},
+	"AIP_LIST_REQUESTS": {
+		Rationale: `AIP-132 defines the shape of List methods so that clients of all resource-oriented APIs look the same. The request and response are named after the RPC, and the response has a repeated field named after the resources, such as "books" for ListBooks.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+}
+
+message ListBooksRequest {
+  string parent = 1;
+  int32 page_size = 2;
+  string page_token = 3;
+}
+
+message ListBooksResponse {
+  repeated Book results = 1;
+  string next_page_token = 2;
+}
+
+service Library {
+  rpc ListBooks(ListBooksRequest) returns (ListBooksResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+}
+
+message ListBooksRequest {
+  string parent = 1;
+  int32 page_size = 2;
+  string page_token = 3;
+}
+
+message ListBooksResponse {
+  repeated Book books = 1;
+  string next_page_token = 2;
+}
+
+service Library {
+  rpc ListBooks(ListBooksRequest) returns (ListBooksResponse);
+}
+`),
+	},
+	"AIP_RESOURCE_NAME_FIELDS": {
+		Rationale: `AIP-122 identifies every resource by a resource name such as "publishers/123/books/456", stored in a string field "name", so that clients can refer to resources the same way in all APIs. The resources are the messages returned by Get RPCs, the resource fields of Create and Update requests, and the repeated resource fields of List responses. Replace identifier fields such as "id" with "name".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string id = 1;
+  string title = 2;
+}
+
+message GetBookRequest {
+  string name = 1;
+}
+
+service Library {
+  rpc GetBook(GetBookRequest) returns (Book);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+  string title = 2;
+}
+
+message GetBookRequest {
+  string name = 1;
+}
+
+service Library {
+  rpc GetBook(GetBookRequest) returns (Book);
+}
+`),
+	},
+	"AIP_UPDATE_MASK_FIELD_MASK": {
+		Rationale: `AIP-134 uses a google.protobuf.FieldMask named "update_mask" to list the fields to update, so that clients can update some fields of a resource without overwriting the others, and so that new fields are not cleared by old clients.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+}
+
+message UpdateBookRequest {
+  Book book = 1;
+  repeated string update_mask = 2;
+}
+
+service Library {
+  rpc UpdateBook(UpdateBookRequest) returns (Book);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+import "google/protobuf/field_mask.proto";
+
+message Book {
+  string name = 1;
+}
+
+message UpdateBookRequest {
+  Book book = 1;
+  google.protobuf.FieldMask update_mask = 2;
+}
+
+service Library {
+  rpc UpdateBook(UpdateBookRequest) returns (Book);
+}
+`),
+	},
+	"AIP_UPDATE_REQUESTS": {
+		Rationale: `AIP-134 defines the shape of Update methods so that clients of all resource-oriented APIs look the same. The request is named after the RPC and has a field named after the resource that contains the resource to update, and the response is the updated resource, or a google.longrunning.Operation if the update is long-running.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+}
+
+message UpdateBookRequest {
+  Book book = 1;
+}
+
+message UpdateBookResponse {
+  Book book = 1;
+}
+
+service Library {
+  rpc UpdateBook(UpdateBookRequest) returns (UpdateBookResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package library.v1;
+
+message Book {
+  string name = 1;
+}
+
+message UpdateBookRequest {
+  Book book = 1;
+}
+
+service Library {
+  rpc UpdateBook(UpdateBookRequest) returns (Book);
+}
+`),
+	},
+	"COMMENTS_NO_C_STYLE": {
+		Rationale: `C-Style comments are not attached to elements consistently by all Protobuf tooling, and mixing comment styles makes files harder to read. Use "//" comments instead. This failure can be fixed with "prototool format --fix".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+/* Foo is a foo. */
+message Foo {}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+// Foo is a foo.
+message Foo {}
+`),
+	},
+	"DELETED_FIELDS_RESERVED": {
+		Rationale: `When a field is deleted, a later change can add a new field with the same number or name. Old clients then read the new field as the deleted one from the binary or JSON encoding, which silently corrupts data. Reserve the number and name of every deleted field. This rule compares against the files at the git branch given with "lint --git-branch", or the FileDescriptorSet written by "prototool break descriptor-set" given with "lint --descriptor-set-path", and does nothing if neither is given.`,
+		Previous: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string one = 1;
+  string two = 2;
+}
+`),
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string one = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  reserved 2;
+  reserved "two";
+  string one = 1;
+}
+`),
+	},
+	"ENUM_FIELD_NAMES_UPPERCASE": {
+		Rationale: `Enum values are constants in most generated code, and uppercase names make them easy to tell apart from messages and fields. Rename the value to be all uppercase.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_INVALID = 0;
+  FOO_one = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_INVALID = 0;
+  FOO_ONE = 1;
+}
+`),
+	},
+	"ENUM_FIELD_NAMES_UPPER_SNAKE_CASE": {
+		Rationale: `The Protobuf style guide uses UPPER_SNAKE_CASE for enum values, and languages such as Go and Java derive the names of their constants from it. Rename the value to be UPPER_SNAKE_CASE.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_INVALID = 0;
+  FooOne = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_INVALID = 0;
+  FOO_ONE = 1;
+}
+`),
+	},
+	"ENUM_FIELD_NUMBERS_UNIQUE": {
+		Rationale: `Enum fields with the same number are aliases, and a value read from the wire can not be mapped back to a single name, which breaks JSON serialization and switch statements in some languages. Give each enum field its own number. Unlike ENUMS_NO_ALLOW_ALIAS, this reports each alias instead of the "allow_alias" option.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  option allow_alias = true;
+  FOO_INVALID = 0;
+  FOO_ONE = 1;
+  FOO_UNO = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_INVALID = 0;
+  FOO_ONE = 1;
+  FOO_TWO = 2;
+}
+`),
+	},
+	"ENUM_FIELD_PREFIXES": {
+		Rationale: `Enum values use C++ scoping rules, so they are siblings of their enum and not children of it. Two enums in the same package or message can not both have a value named ONE. Prefixing every value with the names of its enclosing messages and enum avoids these collisions.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Bar {
+  enum Foo {
+    FOO_INVALID = 0;
+    FOO_ONE = 1;
+  }
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Bar {
+  enum Foo {
+    BAR_FOO_INVALID = 0;
+    BAR_FOO_ONE = 1;
+  }
+}
+`),
+	},
+	"ENUM_FIELD_PREFIXES_EXCEPT_MESSAGE": {
+		Rationale: `Enum values use C++ scoping rules, so they are siblings of their enum and not children of it. Two enums in the same package or message can not both have a value named ONE. Prefixing every value with the name of its enum avoids these collisions. Unlike ENUM_FIELD_PREFIXES, the names of the enclosing messages are not part of the prefix.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  INVALID = 0;
+  ONE = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Bar {
+  enum Foo {
+    FOO_INVALID = 0;
+    FOO_ONE = 1;
+  }
+}
+`),
+	},
+	"ENUM_NAMES_CAMEL_CASE": {
+		Rationale: `Enums become types in generated code, and CamelCase names are valid type names in every supported language. Remove the underscores and other characters from the name.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo_Bar {
+  FOO_BAR_INVALID = 0;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum FooBar {
+  FOO_BAR_INVALID = 0;
+}
+`),
+	},
+	"ENUM_NAMES_CAPITALIZED": {
+		Rationale: `Enums become types in generated code, and a lowercase first letter makes the type unexported in Go. Capitalize the first letter of the name.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum foo {
+  FOO_INVALID = 0;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_INVALID = 0;
+}
+`),
+	},
+	"ENUM_NAMES_UPPER_CAMEL_CASE": {
+		Rationale: `Enums become types in generated code, and the Protobuf style guide uses UpperCamelCase for type names. Rename the enum to be UpperCamelCase.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum fooBar {
+  FOO_BAR_INVALID = 0;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum FooBar {
+  FOO_BAR_INVALID = 0;
+}
+`),
+	},
+	"ENUM_ZERO_VALUES_INVALID": {
+		Rationale: `In proto3, the zero value of an enum is used when a field is not set, so it can not be told apart from an explicitly set zero value. Reserving the zero value as an invalid value makes unset fields detectable. The suffix of the name can be changed with the "suffix" parameter, for example to UNSPECIFIED.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_NONE = 0;
+  FOO_ONE = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_INVALID = 0;
+  FOO_ONE = 1;
+}
+`),
+	},
+	"ENUM_ZERO_VALUES_INVALID_EXCEPT_MESSAGE": {
+		Rationale: `In proto3, the zero value of an enum is used when a field is not set, so it can not be told apart from an explicitly set zero value. Reserving the zero value as an invalid value makes unset fields detectable. Unlike ENUM_ZERO_VALUES_INVALID, the names of the enclosing messages are not part of the name. The suffix of the name can be changed with the "suffix" parameter, for example to UNSPECIFIED.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_NONE = 0;
+  FOO_ONE = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Bar {
+  enum Foo {
+    FOO_INVALID = 0;
+    FOO_ONE = 1;
+  }
+}
+`),
+	},
+	"ENUMS_HAVE_COMMENTS": {
+		Rationale: `Comments on enums are carried over to the generated code and documentation, and starting the comment with the name of the enum follows the Go convention for doc comments. The required prefix can be changed with the "prefix" parameter.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_INVALID = 0;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+// Foo is a foo.
+enum Foo {
+  FOO_INVALID = 0;
+}
+`),
+	},
+	"ENUMS_NO_ALLOW_ALIAS": {
+		Rationale: `Enum aliases give multiple names to the same value, so a value read from the wire can not be mapped back to a single name, which breaks JSON serialization and switch statements in some languages. Remove the alias and use a single name for each value.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  option allow_alias = true;
+  FOO_INVALID = 0;
+  FOO_ONE = 1;
+  FOO_UNO = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+enum Foo {
+  FOO_INVALID = 0;
+  FOO_ONE = 1;
+}
+`),
+	},
+	"FIELD_NUMBERS_ONE_BYTE_FIRST": {
+		Rationale: `The tags of fields with the numbers 1 to 15 are encoded in one byte, while higher numbers take two or more bytes. Once a message has more than 15 fields, the numbers 1 to 15 should all be taken by the most frequently set fields. Move frequently set fields to the unused numbers in a new version of the message, or reserve the numbers if they belonged to deleted fields.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string one = 1;
+  string two = 2;
+  string sixteen = 16;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  reserved 3 to 15;
+  string one = 1;
+  string two = 2;
+  string sixteen = 16;
+}
+`),
+	},
+	"FILE_NAMES_LOWER_SNAKE_CASE": {
+		Rationale: `Code generators name the generated files and classes after the file names, and file names with uppercase letters or dashes result in inconsistent generated names and in problems on case-insensitive file systems. Rename the file to lower_snake_case.proto.`,
+		Bad: map[string]string{
+			"fooBar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+`,
+		},
+		Good: map[string]string{
+			"foo_bar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+`,
+		},
+	},
+	"FILE_NAMES_NOT_RESERVED_WORDS": {
+		Rationale: `Code generators name the generated files, modules and classes after the file names, and a file named after a keyword of the Protobuf language or of Go, Java or Python, such as import.proto, results in generated code that does not compile or can not be imported. Rename the file to a word that describes its contents.`,
+		Bad: map[string]string{
+			"import.proto": `
+syntax = "proto3";
+
+package foo.v1;
+`,
+		},
+		Good: map[string]string{
+			"importer.proto": `
+syntax = "proto3";
+
+package foo.v1;
+`,
+		},
+	},
+	"FILE_OPTIONS_EQUAL_GO_PACKAGE_LAST_TWO_SUFFIX": {
+		Rationale: `Deriving the Go package name from the last two components of the Protobuf package keeps Go package names unique across major versions without renaming imports. Set "go_package" to the last two components of the package. This failure can be fixed with "prototool format --fix".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "foopb";
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "foov1";
+`),
+	},
+	"FILE_OPTIONS_EQUAL_GO_PACKAGE_PB_SUFFIX": {
+		Rationale: `Deriving the Go package name from the Protobuf package makes it predictable, and the "pb" suffix keeps the generated package from colliding with hand-written packages of the same name. Set "go_package" to the last component of the package followed by "pb". This failure can be fixed with "prototool format --fix".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "foo";
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "v1pb";
+`),
+	},
+	"FILE_OPTIONS_EQUAL_JAVA_MULTIPLE_FILES_TRUE": {
+		Rationale: `With "java_multiple_files" set to true, each message, enum and service is generated in its own Java file instead of being nested in a single outer class, which gives shorter and more natural class names.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_multiple_files = false;
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_multiple_files = true;
+`),
+	},
+	"FILE_OPTIONS_EQUAL_JAVA_OUTER_CLASSNAME_PROTO_SUFFIX": {
+		Rationale: `Deriving the Java outer class name from the file name makes it predictable, and the "Proto" suffix keeps it from colliding with the name of a message in the file. Set "java_outer_classname" to the UpperCamelCase file name followed by "Proto".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_outer_classname = "Foo";
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_outer_classname = "FooProto";
+`),
+	},
+	"FILE_OPTIONS_EQUAL_JAVA_PACKAGE_COM_PREFIX": {
+		Rationale: `Java packages conventionally start with a reversed domain name, and deriving the Java package from the Protobuf package makes it predictable. Set "java_package" to "com." followed by the package.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_package = "foo.v1";
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_package = "com.foo.v1";
+`),
+	},
+	"FILE_OPTIONS_GO_PACKAGE_NOT_LONG_FORM": {
+		Rationale: `The long form of "go_package" hardcodes the Go import path in the Protobuf file, which ties the file to a single repository layout. Use the short form and let the generation configuration decide the import path.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "github.com/acme/foo/v1;foov1";
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "foov1";
+`),
+	},
+	"FILE_OPTIONS_GO_PACKAGE_SAME_IN_DIR": {
+		Rationale: `The generated Go code for a directory is put in a single Go package, so all files in the directory must agree on the value of "go_package". Set the same value in all files of the directory.`,
+		Bad: map[string]string{
+			"bar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "barv1";
+`,
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "foov1";
+`,
+		},
+		Good: map[string]string{
+			"bar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "foov1";
+`,
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "foov1";
+`,
+		},
+	},
+	"FILE_OPTIONS_JAVA_MULTIPLE_FILES_SAME_IN_DIR": {
+		Rationale: `Files in the same directory are in the same Java package, and mixing values of "java_multiple_files" makes the layout of the generated classes inconsistent within it. Set the same value in all files of the directory.`,
+		Bad: map[string]string{
+			"bar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+`,
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option java_multiple_files = true;
+`,
+		},
+		Good: map[string]string{
+			"bar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option java_multiple_files = true;
+`,
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option java_multiple_files = true;
+`,
+		},
+	},
+	"FILE_OPTIONS_JAVA_PACKAGE_SAME_IN_DIR": {
+		Rationale: `Files in the same directory are in the same Protobuf package, and splitting them across Java packages makes the generated code hard to find. Set the same value of "java_package" in all files of the directory.`,
+		Bad: map[string]string{
+			"bar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option java_package = "com.bar.v1";
+`,
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option java_package = "com.foo.v1";
+`,
+		},
+		Good: map[string]string{
+			"bar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option java_package = "com.foo.v1";
+`,
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+option java_package = "com.foo.v1";
+`,
+		},
+	},
+	"FILE_OPTIONS_REQUIRE_GO_PACKAGE": {
+		Rationale: `Without "go_package", the Go package name is derived from the Protobuf package by protoc-gen-go, which results in names such as "foo_v1". Set "go_package" explicitly. This failure can be fixed with "prototool format --fix".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option go_package = "foov1";
+`),
+	},
+	"FILE_OPTIONS_REQUIRE_JAVA_MULTIPLE_FILES": {
+		Rationale: `Without "java_multiple_files", all Java classes of a file are nested in a single outer class. Set "java_multiple_files" explicitly so the layout of the generated code is a deliberate choice.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_multiple_files = true;
+`),
+	},
+	"FILE_OPTIONS_REQUIRE_JAVA_OUTER_CLASSNAME": {
+		Rationale: `Without "java_outer_classname", the outer class name is derived from the file name and changes with it. Set "java_outer_classname" explicitly so renaming a file does not break Java code.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_outer_classname = "FooProto";
+`),
+	},
+	"FILE_OPTIONS_REQUIRE_JAVA_PACKAGE": {
+		Rationale: `Without "java_package", the Java package is the Protobuf package, which usually does not follow the Java convention of starting with a reversed domain name. Set "java_package" explicitly.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_package = "com.foo.v1";
+`),
+	},
+	"FILE_OPTIONS_UNSET_JAVA_MULTIPLE_FILES": {
+		Rationale: `Some code generators set "java_multiple_files" themselves, and a value set in the file would conflict with them. Remove the option from the file.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_multiple_files = true;
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+`),
+	},
+	"FILE_OPTIONS_UNSET_JAVA_OUTER_CLASSNAME": {
+		Rationale: `Some code generators set "java_outer_classname" themselves, and a value set in the file would conflict with them. Remove the option from the file.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+option java_outer_classname = "FooProto";
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+`),
+	},
+	"FILE_PATH_MATCHES_PACKAGE": {
+		Rationale: `Files that are in the directory of their package, such as foo/bar/v1/bar.proto for package foo.bar.v1, can be found from the package alone, and the import paths of the files match their packages. The directory is relative to the include path that contains the file, or to the directory of the configuration file if no include path contains the file, as for compilation. Move the file to the directory of its package.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+`),
+		Good: map[string]string{
+			"foo/v1/foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+`,
+		},
+	},
+	"LINT_IGNORE_COMMENTS_USED": {
+		Rationale: `A lint-ignore comment that does not suppress any failure is left over from a failure that was fixed, and it would hide a new failure of the same linter on the element. Remove the comment.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+// Foo is a foo.
+// prototool:lint-ignore MESSAGE_NAMES_CAPITALIZED
+message Foo {}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+// Foo is a foo.
+message Foo {}
+`),
+	},
+	"MESSAGE_FIELD_NAMES": {
+		Rationale: `Consistent field names make APIs predictable, and the JSON names of fields are derived from them. The style is set with the "style" parameter and defaults to lower_snake_case, which is the Protobuf style guide. This failure can be fixed with "prototool format --fix".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string barID = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string bar_id = 1;
+}
+`),
+	},
+	"MESSAGE_FIELD_NAMES_LOWER_CAMEL_CASE": {
+		Rationale: `Some teams use lowerCamelCase field names so that the field names match their JSON names. Rename the field to be lowerCamelCase. This failure can be fixed with "prototool format --fix".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string bar_id = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string barId = 1;
+}
+`),
+	},
+	"MESSAGE_FIELD_NAMES_LOWER_SNAKE_CASE": {
+		Rationale: `The Protobuf style guide uses lower_snake_case for field names, and code generators rely on it to derive idiomatic names in each language. Rename the field to be lower_snake_case. This failure can be fixed with "prototool format --fix".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string barId = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string bar_id = 1;
+}
+`),
+	},
+	"MESSAGE_FIELD_NAMES_LOWERCASE": {
+		Rationale: `Code generators derive the names of accessors from field names, and uppercase letters in them lead to inconsistent names across languages. Rename the field to be all lowercase. This failure can be fixed with "prototool format --fix".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string barId = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string bar_id = 1;
+}
+`),
+	},
+	"MESSAGE_FIELDS_NOT_FLOATS": {
+		Rationale: `Floating point types can not represent most decimal values exactly, which leads to rounding errors for values such as prices. Use an integer in the smallest unit you need, such as micros. Specific types can be allowed with the "allowed_types" parameter.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  double price = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  int64 price_micros = 1;
+}
+`),
+	},
+	"MESSAGE_NAMES_CAMEL_CASE": {
+		Rationale: `Messages become types in generated code, and CamelCase names are valid type names in every supported language. Remove the underscores and other characters from the name.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo_Bar {}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message FooBar {}
+`),
+	},
+	"MESSAGE_NAMES_CAPITALIZED": {
+		Rationale: `Messages become types in generated code, and a lowercase first letter makes the type unexported in Go. Capitalize the first letter of the name.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message foo {}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {}
+`),
+	},
+	"MESSAGE_NAMES_UPPER_CAMEL_CASE": {
+		Rationale: `Messages become types in generated code, and the Protobuf style guide uses UpperCamelCase for type names. Rename the message to be UpperCamelCase.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message fooBar {}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message FooBar {}
+`),
+	},
+	"MESSAGES_HAVE_COMMENTS": {
+		Rationale: `Comments on messages are carried over to the generated code and documentation, and starting the comment with the name of the message follows the Go convention for doc comments. The required prefix can be changed with the "prefix" parameter.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+// Foo is a foo.
+message Foo {}
+`),
+	},
+	"MESSAGES_HAVE_COMMENTS_EXCEPT_REQUEST_RESPONSE_TYPES": {
+		Rationale: `Comments on messages are carried over to the generated code and documentation. Request and response types are documented by the comment on their RPC, so they do not need their own comment. The required prefix can be changed with the "prefix" parameter.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {}
+
+message GetFooRequest {}
+
+message GetFooResponse {
+  Foo foo = 1;
+}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+// Foo is a foo.
+message Foo {}
+
+message GetFooRequest {}
+
+message GetFooResponse {
+  Foo foo = 1;
+}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"ONEOF_NAMES_LOWER_SNAKE_CASE": {
+		Rationale: `Oneofs are named like fields, and the Protobuf style guide uses lower_snake_case for field names. Rename the oneof to be lower_snake_case.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  oneof barValue {
+    string bar_string = 1;
+    int64 bar_int = 2;
+  }
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  oneof bar_value {
+    string bar_string = 1;
+    int64 bar_int = 2;
+  }
+}
+`),
+	},
+	"PACKAGE_IMPORT_RULES": {
+		Rationale: `In a large repository, packages are layered so that lower-level packages such as common types do not depend on higher-level packages such as services. An import in the wrong direction couples the layers, and often leads to import cycles later. Configure the forbidden imports with the "forbidden" parameter, and move the imported types to a package that the importing package may depend on.`,
+		Parameters: map[string]string{
+			"forbidden": "foo.common.** -> foo.service.**",
+		},
+		Bad: map[string]string{
+			"foo/common/v1/common.proto": `
+syntax = "proto3";
+
+package foo.common.v1;
+
+import "foo/service/v1/service.proto";
+
+message Money {
+  foo.service.v1.Currency currency = 1;
+}
+`,
+			"foo/service/v1/service.proto": `
+syntax = "proto3";
+
+package foo.service.v1;
+
+enum Currency {
+  CURRENCY_INVALID = 0;
+}
+`,
+		},
+		Good: map[string]string{
+			"foo/common/v1/common.proto": `
+syntax = "proto3";
+
+package foo.common.v1;
+
+enum Currency {
+  CURRENCY_INVALID = 0;
+}
+`,
+			"foo/service/v1/service.proto": `
+syntax = "proto3";
+
+package foo.service.v1;
+
+import "foo/common/v1/common.proto";
+
+message Money {
+  foo.common.v1.Currency currency = 1;
+}
+`,
+		},
+	},
+	"PACKAGE_IS_DECLARED": {
+		Rationale: `Files without a package put all their types in the global namespace, where they can collide with the types of any other file. Declare a package.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+message Foo {}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {}
+`),
+	},
+	"PACKAGE_LOWER_CAMEL_CASE": {
+		Rationale: `Packages become namespaces in generated code, and consistent package names make them predictable. Rename the package so that each component is lowerCamelCase.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo_bar.v1;
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package fooBar.v1;
+`),
+	},
+	"PACKAGE_LOWER_SNAKE_CASE": {
+		Rationale: `Packages become namespaces in generated code, and the Protobuf style guide uses lower_snake_case for them. Rename the package so that each component is lower_snake_case.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package fooBar.v1;
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo_bar.v1;
+`),
+	},
+	"PACKAGE_MAJOR_VERSIONED": {
+		Rationale: `Versioning packages lets a breaking change be made in a new major version, such as foo.v2, while clients keep using the old one. Add a major version as the last component of the package.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo;
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+`),
+	},
+	"PACKAGES_NO_IMPORT_CYCLES": {
+		Rationale: `protoc rejects import cycles between files, but not between packages, where a file of one package imports a file of another package that imports a different file of the first package. Languages such as Go generate one package per Protobuf package and can not compile import cycles between them. Move the types that cause the cycle into one of the packages, or into a new package that both import.`,
+		Bad: map[string]string{
+			"bar/v1/bar.proto": `
+syntax = "proto3";
+
+package bar.v1;
+
+import "foo/v1/baz.proto";
+
+message Bar {
+  foo.v1.Baz baz = 1;
+}
+`,
+			"foo/v1/baz.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+message Baz {}
+`,
+			"foo/v1/foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+import "bar/v1/bar.proto";
+
+message Foo {
+  bar.v1.Bar bar = 1;
+}
+`,
+		},
+		Good: map[string]string{
+			"bar/v1/bar.proto": `
+syntax = "proto3";
+
+package bar.v1;
+
+message Bar {
+  Baz baz = 1;
+}
+
+message Baz {}
+`,
+			"foo/v1/foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+import "bar/v1/bar.proto";
+
+message Foo {
+  bar.v1.Bar bar = 1;
+}
+`,
+		},
+	},
+	"PACKAGES_SAME_IN_DIR": {
+		Rationale: `Most languages generate a single package for all files in a directory, so files in the same directory with different Protobuf packages result in broken or confusing code. Move the files to a directory per package.`,
+		Bad: map[string]string{
+			"bar.proto": `
+syntax = "proto3";
+
+package bar.v1;
+`,
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+`,
+		},
+		Good: map[string]string{
+			"bar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+`,
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+`,
+		},
+	},
+	"RPCS_HAVE_COMMENTS": {
+		Rationale: `Comments on RPCs are carried over to the generated code and documentation, and starting the comment with the name of the RPC follows the Go convention for doc comments. The required prefix can be changed with the "prefix" parameter.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  // GetFoo gets a foo.
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"RPC_NAMES_CAMEL_CASE": {
+		Rationale: `RPCs become methods in generated code, and CamelCase names are valid method names in every supported language. Remove the underscores and other characters from the name.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc Get_Foo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"RPC_NAMES_CAPITALIZED": {
+		Rationale: `RPCs become methods in generated code, and a lowercase first letter makes the method unexported in Go. Capitalize the first letter of the name.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc getFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"RPC_NAMES_LOWER_CAMEL_CASE": {
+		Rationale: `Some teams use lowerCamelCase RPC names so that they match the method names of their JavaScript clients. Rename the RPC to be lowerCamelCase.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc getFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"REQUEST_RESPONSE_TYPES_IN_SAME_FILE": {
+		Rationale: `Request and response types are part of the definition of their RPC, and keeping them in the same file as the service makes the API readable in one place. Move the request and response types to the file of the service as top-level messages.`,
+		Bad: map[string]string{
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+import "foo_messages.proto";
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`,
+			"foo_messages.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+`,
+		},
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"REQUEST_RESPONSE_TYPES_UNIQUE": {
+		Rationale: `A request or response type that is shared by multiple RPCs can not change for one of them without changing the others. Give each RPC its own request and response types, even if they have the same fields.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message FooRequest {}
+
+message FooResponse {}
+
+service FooAPI {
+  rpc GetFoo(FooRequest) returns (FooResponse);
+  rpc DeleteFoo(FooRequest) returns (FooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+message DeleteFooRequest {}
+
+message DeleteFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+  rpc DeleteFoo(DeleteFooRequest) returns (DeleteFooResponse);
+}
+`),
+	},
+	"REQUEST_RESPONSE_NAMES_MATCH_RPC": {
+		Rationale: `Naming request and response types after their RPC makes the types of an RPC predictable and avoids sharing them between RPCs. Rename the types to the name of the RPC followed by "Request" and "Response".`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message FooRequest {}
+
+message FooResponse {}
+
+service FooAPI {
+  rpc GetFoo(FooRequest) returns (FooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"REQUEST_RESPONSE_NAMES_MATCH_SERVICE_RPC": {
+		Rationale: `Naming request and response types after their service and RPC keeps the names unique when multiple services in a package have RPCs of the same name. Rename the types to the name of the service and the RPC followed by "Request" and "Response". Well-Known Types are allowed.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetRequest {}
+
+message GetResponse {}
+
+service Foo {
+  rpc Get(GetRequest) returns (GetResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message FooGetRequest {}
+
+message FooGetResponse {}
+
+service Foo {
+  rpc Get(FooGetRequest) returns (FooGetResponse);
+}
+`),
+	},
+	"SERVICES_HAVE_COMMENTS": {
+		Rationale: `Comments on services are carried over to the generated code and documentation, and starting the comment with the name of the service follows the Go convention for doc comments. The required prefix can be changed with the "prefix" parameter.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+// FooAPI is the API for foos.
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"SERVICE_NAMES_CAMEL_CASE": {
+		Rationale: `Services become types in generated code, and CamelCase names are valid type names in every supported language. Remove the underscores and other characters from the name.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service Foo_API {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"SERVICE_NAMES_CAPITALIZED": {
+		Rationale: `Services become types in generated code, and a lowercase first letter makes the type unexported in Go. Capitalize the first letter of the name.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service fooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"SERVICE_NAMES_UPPER_CAMEL_CASE": {
+		Rationale: `Services become types in generated code, and the Protobuf style guide uses UpperCamelCase for type names. Rename the service to be UpperCamelCase.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service fooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message GetFooRequest {}
+
+message GetFooResponse {}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"SYNTAX_PROTO3": {
+		Rationale: `proto3 is simpler than proto2 and is the only syntax supported by some languages and by the JSON mapping. Use proto3, and replace required fields and default values with validation in your code.`,
+		Bad: fooProto(`
+syntax = "proto2";
+
+package foo.v1;
+
+message Foo {
+  optional string bar = 1;
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {
+  string bar = 1;
+}
+`),
+	},
+	"UNUSED_IMPORTS": {
+		Rationale: `Unused imports make files depend on each other for no reason, which slows down builds and makes it harder to move or delete files. protoc rejects unused imports, unless "protoc.allow_unused_imports" is set in the configuration file, in which case this rule reports them instead. Imports that are only used for custom options are used. Remove the import.`,
+		Bad: map[string]string{
+			"bar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+message Bar {}
+`,
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+import "bar.proto";
+
+message Foo {}
+`,
+		},
+		Good: map[string]string{
+			"bar.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+message Bar {}
+`,
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+import "bar.proto";
+
+message Foo {
+  Bar bar = 1;
+}
+`,
+		},
+	},
+	"UNUSED_TYPES": {
+		Rationale: `Messages and enums that no RPC uses, directly or through the fields of other messages, are usually left over from deleted RPCs and fields. All files of the directories being linted are checked together, so types can be used from other files. Types that are used outside of these files, such as events published to a message queue, can be configured as roots with the "roots" parameter. Delete the unused type, or add it to the roots.`,
+		Bad: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {}
+
+message Bar {}
+
+message GetFooRequest {}
+
+message GetFooResponse {
+  Foo foo = 1;
+}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+message Foo {}
+
+message GetFooRequest {}
+
+message GetFooResponse {
+  Foo foo = 1;
+}
+
+service FooAPI {
+  rpc GetFoo(GetFooRequest) returns (GetFooResponse);
+}
+`),
+	},
+	"WKT_DIRECTLY_IMPORTED": {
+		Rationale: `The Well-Known Types are provided with protoc and most languages ship pre-generated code for them under the import path "google/protobuf/". Importing a copy of them from another path generates a second, incompatible definition. Import them from "google/protobuf/" and delete the copy.`,
+		Bad: map[string]string{
+			"foo.proto": `
+syntax = "proto3";
+
+package foo.v1;
+
+import "third_party/google/protobuf/timestamp.proto";
+
+message Foo {
+  google.protobuf.Timestamp time = 1;
+}
+`,
+			"third_party/google/protobuf/timestamp.proto": `
+syntax = "proto3";
+
+package google.protobuf;
+
+message Timestamp {
+  int64 seconds = 1;
+  int32 nanos = 2;
+}
+`,
+		},
+		Good: fooProto(`
+syntax = "proto3";
+
+package foo.v1;
+
+import "google/protobuf/timestamp.proto";
+
+message Foo {
+  google.protobuf.Timestamp time = 1;
+}
+`),
+	},
+}
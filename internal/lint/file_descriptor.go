@@ -0,0 +1,127 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"strings"
+	"text/scanner"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/location"
+)
+
+// FileDescriptor is a compiled file to lint.
+type FileDescriptor struct {
+	*descriptor.FileDescriptorProto
+	// Filename is the display path of the file, which is the
+	// same as the Filename of the parsed proto.Proto.
+	Filename string
+	// FileDescriptorSet contains the file and all of its imports,
+	// so that types can be resolved across files.
+	FileDescriptorSet *descriptor.FileDescriptorSet
+	// PreviousFileDescriptorSet contains the files at a previous git
+	// branch or FileDescriptorSet snapshot, so that deleted types can
+	// be detected. This is nil if no previous state was given.
+	PreviousFileDescriptorSet *descriptor.FileDescriptorSet
+
+	finder *location.Finder
+}
+
+func newFileDescriptor(fileDescriptorProto *descriptor.FileDescriptorProto, filename string, fileDescriptorSet *descriptor.FileDescriptorSet) *FileDescriptor {
+	return &FileDescriptor{
+		FileDescriptorProto: fileDescriptorProto,
+		Filename:            filename,
+		FileDescriptorSet:   fileDescriptorSet,
+		finder:              location.NewFinder(fileDescriptorProto.GetSourceCodeInfo()),
+	}
+}
+
+// Position returns the position of the element at the location path,
+// such as location.Path{}.Scope(location.Message, 0) for the first message.
+//
+// If there is no source code info for the path, the position only has
+// the filename set.
+func (f *FileDescriptor) Position(path location.Path) scanner.Position {
+	position := scanner.Position{
+		Filename: f.Filename,
+	}
+	if loc, ok := f.finder.Find(path); ok {
+		position.Line = int(loc.Span.Line())
+		position.Column = int(loc.Span.Col())
+	}
+	return position
+}
+
+// descriptorMessage is a message in a FileDescriptorSet.
+type descriptorMessage struct {
+	*descriptor.DescriptorProto
+	// file is the file the message is defined in.
+	file *descriptor.FileDescriptorProto
+	// nested is true if the message is nested in another message.
+	nested bool
+	// path is the location path of the message in its file.
+	path location.Path
+}
+
+// getTypeNameToMessage returns the messages in the FileDescriptorSet,
+// including nested messages, keyed by their fully-qualified type names
+// with a leading dot, such as .foo.Bar, which is the form of type names
+// in descriptors.
+func getTypeNameToMessage(fileDescriptorSet *descriptor.FileDescriptorSet) map[string]*descriptorMessage {
+	typeNameToMessage := make(map[string]*descriptorMessage)
+	for _, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+		prefix := "."
+		if pkg := fileDescriptorProto.GetPackage(); pkg != "" {
+			prefix = "." + pkg + "."
+		}
+		addTypeNameToMessage(typeNameToMessage, fileDescriptorProto, prefix, nil, fileDescriptorProto.GetMessageType())
+	}
+	return typeNameToMessage
+}
+
+// addTypeNameToMessage adds the messages, which are nested in the message
+// at the parent path, or are top-level messages if the parent path is nil.
+func addTypeNameToMessage(typeNameToMessage map[string]*descriptorMessage, fileDescriptorProto *descriptor.FileDescriptorProto, prefix string, parentPath location.Path, descriptorProtos []*descriptor.DescriptorProto) {
+	for i, descriptorProto := range descriptorProtos {
+		typeName := prefix + descriptorProto.GetName()
+		path := location.Path{}.Scope(location.Message, i)
+		if parentPath != nil {
+			path = parentPath.Scope(location.NestedType, i)
+		}
+		typeNameToMessage[typeName] = &descriptorMessage{
+			DescriptorProto: descriptorProto,
+			file:            fileDescriptorProto,
+			nested:          parentPath != nil,
+			path:            path,
+		}
+		addTypeNameToMessage(typeNameToMessage, fileDescriptorProto, typeName+".", path, descriptorProto.GetNestedType())
+	}
+}
+
+// getRelativeTypeName returns the type name without the leading dot, and
+// without the package if the type is in the package of the file.
+func getRelativeTypeName(fileDescriptor *FileDescriptor, typeName string) string {
+	typeName = strings.TrimPrefix(typeName, ".")
+	if pkg := fileDescriptor.GetPackage(); pkg != "" {
+		return strings.TrimPrefix(typeName, pkg+".")
+	}
+	return typeName
+}
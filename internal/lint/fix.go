@@ -0,0 +1,304 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package lint
+
+import (
+	"strings"
+	"text/scanner"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/strs"
+	"github.com/uber/prototool/internal/text"
+)
+
+// getFixedName returns the result of fix for the name if the result
+// is valid, and the empty string otherwise.
+func getFixedName(name string, fix func(string) string, valid func(string) bool) string {
+	fixedName := fix(name)
+	if !valid(fixedName) {
+		return ""
+	}
+	return fixedName
+}
+
+// toCamelCase converts s to CamelCase, keeping the case of the first letter.
+func toCamelCase(s string) string {
+	if strs.IsCapitalized(s) {
+		return strs.ToUpperCamelCase(s)
+	}
+	return strs.ToLowerCamelCase(s)
+}
+
+// toCapitalized uppercases the first letter of s.
+func toCapitalized(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// getUnusedEnumFieldName returns the name if the enum of the enum field
+// does not have another field with the name, and the empty string otherwise.
+func getUnusedEnumFieldName(enumField *proto.EnumField, name string) string {
+	enum, ok := enumField.Parent.(*proto.Enum)
+	if !ok {
+		return ""
+	}
+	for _, element := range enum.Elements {
+		if other, ok := element.(*proto.EnumField); ok && other.Name == name {
+			return ""
+		}
+	}
+	return name
+}
+
+// lineColumn is the line and column of an element.
+type lineColumn struct {
+	line   int
+	column int
+}
+
+// typeReference is a reference to a message or enum type by an element,
+// such as the type of a field or the request type of an RPC.
+type typeReference struct {
+	position scanner.Position
+	// scope is the package and the names of the messages the reference
+	// is in, which are the scopes the reference is resolved in.
+	scope []string
+	name  string
+}
+
+// addReferenceEdits adds the edits that update the references to renamed
+// messages and enums in all of the descriptors to the failures that have
+// edits to rename messages or enums.
+//
+// References to enum values, such as [default = FOO] or the values of
+// custom options, are not updated, so the edits to rename enum values
+// that are used as option values anywhere in the descriptors are dropped.
+func addReferenceEdits(dirPathToDescriptors map[string][]*proto.Proto, failures []*text.Failure) {
+	filenameToTypes := make(map[string]map[lineColumn]string)
+	filenameToEnumValues := make(map[string]map[lineColumn]struct{})
+	fullNames := make(map[string]struct{})
+	optionIdentifiers := make(map[string]struct{})
+	var references []*typeReference
+	for _, descriptors := range dirPathToDescriptors {
+		for _, descriptor := range descriptors {
+			positionToType := make(map[lineColumn]string)
+			pkg := getPackageScope(descriptor)
+			for i := range pkg {
+				fullNames[strings.Join(pkg[:i+1], ".")] = struct{}{}
+			}
+			addTypes(descriptor.Elements, pkg, positionToType, fullNames, &references)
+			filenameToTypes[descriptor.Filename] = positionToType
+			enumValuePositions := make(map[lineColumn]struct{})
+			addEnumValuesAndOptionIdentifiers(descriptor.Elements, enumValuePositions, optionIdentifiers)
+			filenameToEnumValues[descriptor.Filename] = enumValuePositions
+		}
+	}
+	for _, failure := range failures {
+		var referenceEdits []*text.Edit
+		edits := failure.Edits[:0]
+		for _, edit := range failure.Edits {
+			if _, ok := filenameToEnumValues[edit.Filename][lineColumn{line: edit.Line, column: edit.Column}]; ok && !edit.Reference {
+				if _, ok := optionIdentifiers[edit.Old]; ok {
+					continue
+				}
+			}
+			edits = append(edits, edit)
+		}
+		failure.Edits = edits
+		for _, edit := range failure.Edits {
+			if edit.Reference {
+				continue
+			}
+			fullName, ok := filenameToTypes[edit.Filename][lineColumn{line: edit.Line, column: edit.Column}]
+			if !ok || fullName[strings.LastIndex(fullName, ".")+1:] != edit.Old {
+				continue
+			}
+			for _, reference := range references {
+				if referenceEdit := getReferenceEdit(fullNames, reference, fullName, edit.New); referenceEdit != nil {
+					referenceEdits = append(referenceEdits, referenceEdit)
+				}
+			}
+		}
+		failure.Edits = append(failure.Edits, referenceEdits...)
+	}
+}
+
+func getPackageScope(descriptor *proto.Proto) []string {
+	for _, element := range descriptor.Elements {
+		if pkg, ok := element.(*proto.Package); ok && pkg.Name != "" {
+			return strings.Split(pkg.Name, ".")
+		}
+	}
+	return nil
+}
+
+// addTypes adds the fully-qualified names of the messages and enums of
+// the elements by position and to the set of fully-qualified names,
+// and adds the type references of the elements.
+func addTypes(elements []proto.Visitee, scope []string, positionToType map[lineColumn]string, fullNames map[string]struct{}, references *[]*typeReference) {
+	addType := func(position scanner.Position, name string) []string {
+		typeScope := append(append([]string{}, scope...), name)
+		fullName := strings.Join(typeScope, ".")
+		positionToType[lineColumn{line: position.Line, column: position.Column}] = fullName
+		fullNames[fullName] = struct{}{}
+		return typeScope
+	}
+	addReference := func(position scanner.Position, name string) {
+		*references = append(*references, &typeReference{position: position, scope: scope, name: name})
+	}
+	for _, element := range elements {
+		switch e := element.(type) {
+		case *proto.Message:
+			if e.IsExtend {
+				addReference(e.Position, e.Name)
+				addTypes(e.Elements, scope, positionToType, fullNames, references)
+				continue
+			}
+			addTypes(e.Elements, addType(e.Position, e.Name), positionToType, fullNames, references)
+		case *proto.Group:
+			addTypes(e.Elements, addType(e.Position, e.Name), positionToType, fullNames, references)
+		case *proto.Enum:
+			addType(e.Position, e.Name)
+		case *proto.Oneof:
+			addTypes(e.Elements, scope, positionToType, fullNames, references)
+		case *proto.NormalField:
+			addReference(e.Position, e.Type)
+		case *proto.MapField:
+			addReference(e.Position, e.Type)
+		case *proto.OneOfField:
+			addReference(e.Position, e.Type)
+		case *proto.Service:
+			addTypes(e.Elements, scope, positionToType, fullNames, references)
+		case *proto.RPC:
+			addReference(e.Position, e.RequestType)
+			addReference(e.Position, e.ReturnsType)
+		}
+	}
+}
+
+// addEnumValuesAndOptionIdentifiers adds the positions of the enum values
+// of the elements, and the identifiers that are used as the values of the
+// options of the elements, such as FOO for [default = FOO].
+func addEnumValuesAndOptionIdentifiers(elements []proto.Visitee, enumValuePositions map[lineColumn]struct{}, optionIdentifiers map[string]struct{}) {
+	addOptions := func(options ...*proto.Option) {
+		for _, option := range options {
+			if option != nil {
+				addLiteralIdentifiers(&option.Constant, optionIdentifiers)
+			}
+		}
+	}
+	for _, element := range elements {
+		switch e := element.(type) {
+		case *proto.Option:
+			addOptions(e)
+		case *proto.Message:
+			addEnumValuesAndOptionIdentifiers(e.Elements, enumValuePositions, optionIdentifiers)
+		case *proto.Group:
+			addEnumValuesAndOptionIdentifiers(e.Elements, enumValuePositions, optionIdentifiers)
+		case *proto.Enum:
+			addEnumValuesAndOptionIdentifiers(e.Elements, enumValuePositions, optionIdentifiers)
+		case *proto.EnumField:
+			enumValuePositions[lineColumn{line: e.Position.Line, column: e.Position.Column}] = struct{}{}
+			addOptions(e.ValueOption)
+		case *proto.Oneof:
+			addEnumValuesAndOptionIdentifiers(e.Elements, enumValuePositions, optionIdentifiers)
+		case *proto.NormalField:
+			addOptions(e.Options...)
+		case *proto.MapField:
+			addOptions(e.Options...)
+		case *proto.OneOfField:
+			addOptions(e.Options...)
+		case *proto.Service:
+			addEnumValuesAndOptionIdentifiers(e.Elements, enumValuePositions, optionIdentifiers)
+		case *proto.RPC:
+			addEnumValuesAndOptionIdentifiers(e.Elements, enumValuePositions, optionIdentifiers)
+		}
+	}
+}
+
+// addLiteralIdentifiers adds the identifiers of the literal, including
+// the identifiers in its array and aggregate values.
+func addLiteralIdentifiers(literal *proto.Literal, identifiers map[string]struct{}) {
+	if literal.Source != "" && !literal.IsString {
+		identifiers[literal.Source] = struct{}{}
+	}
+	for _, child := range literal.Array {
+		addLiteralIdentifiers(child, identifiers)
+	}
+	for _, namedLiteral := range literal.OrderedMap {
+		if namedLiteral.Literal != nil {
+			addLiteralIdentifiers(namedLiteral.Literal, identifiers)
+		}
+	}
+}
+
+// getReferenceEdit returns the edit that updates the reference if it
+// references the type with the given fully-qualified name or one of its
+// nested types, and nil otherwise.
+func getReferenceEdit(fullNames map[string]struct{}, reference *typeReference, fullName string, newName string) *text.Edit {
+	resolvedName := resolveTypeReference(fullNames, reference)
+	if resolvedName != fullName && !strings.HasPrefix(resolvedName, fullName+".") {
+		return nil
+	}
+	prefix := ""
+	if strings.HasPrefix(reference.name, ".") {
+		prefix = "."
+	}
+	components := strings.Split(strings.TrimPrefix(reference.name, "."), ".")
+	// the reference only contains the last components of the resolved name
+	index := strings.Count(fullName, ".") - (strings.Count(resolvedName, ".") + 1 - len(components))
+	if index < 0 {
+		return nil
+	}
+	edit := text.NewEdit(
+		reference.position,
+		prefix+strings.Join(components[:index+1], "."),
+		prefix+strings.Join(append(components[:index:index], newName), "."),
+	)
+	edit.Reference = true
+	return edit
+}
+
+// resolveTypeReference returns the fully-qualified name of the type the
+// reference refers to, or the empty string if it cannot be resolved.
+//
+// Like protoc, the first component of the name is looked up in the
+// scope of the reference, then in each of the enclosing scopes.
+func resolveTypeReference(fullNames map[string]struct{}, reference *typeReference) string {
+	if strings.HasPrefix(reference.name, ".") {
+		return strings.TrimPrefix(reference.name, ".")
+	}
+	first := reference.name
+	if i := strings.Index(first, "."); i >= 0 {
+		first = first[:i]
+	}
+	for i := len(reference.scope); i >= 0; i-- {
+		scope := strings.Join(reference.scope[:i], ".")
+		if scope != "" {
+			scope += "."
+		}
+		if _, ok := fullNames[scope+first]; ok {
+			return scope + reference.name
+		}
+	}
+	return ""
+}
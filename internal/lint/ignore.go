@@ -0,0 +1,126 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/emicklei/proto"
+)
+
+// ignores are the files and packages to ignore for each linter ID.
+type ignores struct {
+	idToFilePaths map[string][]string
+	idToPackages  map[string][]string
+}
+
+// shouldIgnore returns true if the linter with the given ID should be
+// ignored for the file with the given path and Protobuf package.
+//
+// A file is ignored if an ignored file path is equal to the path, is a
+// parent directory of the path, or is a glob pattern that matches the path.
+// Glob patterns are matched per path element, where ** matches any number
+// of path elements.
+//
+// A package is ignored if an ignored package is a glob pattern that matches
+// the package, matched per package component, so foo.v1alpha.* matches
+// foo.v1alpha.bar, and foo.** matches foo and all of its sub-packages.
+func (i *ignores) shouldIgnore(id string, filePath string, pkg string) (bool, error) {
+	var err error
+	if !filepath.IsAbs(filePath) {
+		filePath, err = filepath.Abs(filePath)
+		if err != nil {
+			return false, err
+		}
+	}
+	for _, ignoreFilePath := range i.idToFilePaths[id] {
+		if filePath == ignoreFilePath || strings.HasPrefix(filePath, ignoreFilePath+string(filepath.Separator)) {
+			return true, nil
+		}
+		matches, err := matchGlob(filepath.ToSlash(ignoreFilePath), filepath.ToSlash(filePath), "/")
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	if pkg == "" {
+		return false, nil
+	}
+	for _, ignorePackage := range i.idToPackages[id] {
+		matches, err := matchGlob(ignorePackage, pkg, ".")
+		if err != nil {
+			return false, err
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchGlob returns true if the name matches the pattern, where both
+// are split into elements by the separator.
+func matchGlob(pattern string, name string, separator string) (bool, error) {
+	return matchGlobElements(strings.Split(pattern, separator), strings.Split(name, separator))
+}
+
+func matchGlobElements(patternElements []string, nameElements []string) (bool, error) {
+	for len(patternElements) > 0 {
+		if patternElements[0] == "**" {
+			patternElements = patternElements[1:]
+			if len(patternElements) == 0 {
+				return true, nil
+			}
+			for i := 0; i <= len(nameElements); i++ {
+				matches, err := matchGlobElements(patternElements, nameElements[i:])
+				if err != nil || matches {
+					return matches, err
+				}
+			}
+			return false, nil
+		}
+		if len(nameElements) == 0 {
+			return false, nil
+		}
+		matches, err := path.Match(patternElements[0], nameElements[0])
+		if err != nil || !matches {
+			return false, err
+		}
+		patternElements = patternElements[1:]
+		nameElements = nameElements[1:]
+	}
+	return len(nameElements) == 0, nil
+}
+
+// getPackageName returns the name of the package of the descriptor,
+// or empty if the descriptor has no package.
+func getPackageName(descriptor *proto.Proto) string {
+	for _, element := range descriptor.Elements {
+		if pkg, ok := element.(*proto.Package); ok {
+			return pkg.Name
+		}
+	}
+	return ""
+}
@@ -23,9 +23,11 @@ package lint
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/emicklei/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/uber/prototool/internal/file"
 	"github.com/uber/prototool/internal/settings"
 	"github.com/uber/prototool/internal/text"
@@ -35,9 +37,19 @@ import (
 var (
 	// AllLinters is the slice of all known Linters.
 	AllLinters = []Linter{
+		aipCreateRequestsLinter,
+		aipDeleteRequestsLinter,
+		aipGetRequestsLinter,
+		aipListPaginationLinter,
+		aipListRequestsLinter,
+		aipResourceNameFieldsLinter,
+		aipUpdateMaskFieldMaskLinter,
+		aipUpdateRequestsLinter,
 		commentsNoCStyleLinter,
+		deletedFieldsReservedLinter,
 		enumFieldNamesUppercaseLinter,
 		enumFieldNamesUpperSnakeCaseLinter,
+		enumFieldNumbersUniqueLinter,
 		enumFieldPrefixesLinter,
 		enumFieldPrefixesExceptMessageLinter,
 		enumNamesCamelCaseLinter,
@@ -47,6 +59,9 @@ var (
 		enumZeroValuesInvalidExceptMessageLinter,
 		enumsHaveCommentsLinter,
 		enumsNoAllowAliasLinter,
+		fieldNumbersOneByteFirstLinter,
+		fileNamesLowerSnakeCaseLinter,
+		fileNamesNotReservedWordsLinter,
 		fileOptionsEqualGoPackageLastTwoSuffixLinter,
 		fileOptionsEqualGoPackagePbSuffixLinter,
 		fileOptionsEqualJavaMultipleFilesTrueLinter,
@@ -62,7 +77,10 @@ var (
 		fileOptionsRequireJavaPackageLinter,
 		fileOptionsUnsetJavaMultipleFilesLinter,
 		fileOptionsUnsetJavaOuterClassnameLinter,
+		filePathMatchesPackageLinter,
+		lintIgnoreCommentsUsedLinter,
 		messageFieldsNotFloatsLinter,
+		messageFieldNamesLinter,
 		messageFieldNamesLowerCamelCaseLinter,
 		messageFieldNamesLowerSnakeCaseLinter,
 		messageFieldNamesLowercaseLinter,
@@ -72,10 +90,12 @@ var (
 		messagesHaveCommentsLinter,
 		messagesHaveCommentsExceptRequestResponseTypesLinter,
 		oneofNamesLowerSnakeCaseLinter,
+		packageImportRulesLinter,
 		packageIsDeclaredLinter,
 		packageLowerCamelCaseLinter,
 		packageLowerSnakeCaseLinter,
 		packageMajorVersionedLinter,
+		packagesNoImportCyclesLinter,
 		packagesSameInDirLinter,
 		rpcsHaveCommentsLinter,
 		rpcNamesCamelCaseLinter,
@@ -90,39 +110,94 @@ var (
 		serviceNamesCapitalizedLinter,
 		serviceNamesUpperCamelCaseLinter,
 		syntaxProto3Linter,
+		unusedImportsLinter,
+		unusedTypesLinter,
 		wktDirectlyImportedLinter,
 	}
 
 	// DefaultLinters is the slice of default Linters.
 	DefaultLinters = copyLintersWithout(
 		AllLinters,
+		aipCreateRequestsLinter,
+		aipDeleteRequestsLinter,
+		aipGetRequestsLinter,
+		aipListPaginationLinter,
+		aipListRequestsLinter,
+		aipResourceNameFieldsLinter,
+		aipUpdateMaskFieldMaskLinter,
+		aipUpdateRequestsLinter,
+		deletedFieldsReservedLinter,
 		enumFieldNamesUppercaseLinter,
+		enumFieldNumbersUniqueLinter,
 		enumFieldPrefixesExceptMessageLinter,
 		enumsHaveCommentsLinter,
 		enumZeroValuesInvalidExceptMessageLinter,
+		fieldNumbersOneByteFirstLinter,
+		fileNamesLowerSnakeCaseLinter,
+		fileNamesNotReservedWordsLinter,
 		fileOptionsEqualGoPackageLastTwoSuffixLinter,
 		fileOptionsUnsetJavaMultipleFilesLinter,
 		fileOptionsUnsetJavaOuterClassnameLinter,
+		filePathMatchesPackageLinter,
+		lintIgnoreCommentsUsedLinter,
 		messageFieldsNotFloatsLinter,
 		messagesHaveCommentsLinter,
 		messagesHaveCommentsExceptRequestResponseTypesLinter,
+		messageFieldNamesLinter,
 		messageFieldNamesLowercaseLinter,
+		packageImportRulesLinter,
 		packageMajorVersionedLinter,
+		packagesNoImportCyclesLinter,
 		requestResponseNamesMatchRPCLinter,
 		rpcsHaveCommentsLinter,
 		servicesHaveCommentsLinter,
+		unusedImportsLinter,
+		unusedTypesLinter,
 	)
 
+	// AIPLinters is the slice of Linters for resource-oriented APIs that
+	// follow the Google API Improvement Proposals at https://google.aip.dev.
+	AIPLinters = []Linter{
+		aipCreateRequestsLinter,
+		aipDeleteRequestsLinter,
+		aipGetRequestsLinter,
+		aipListPaginationLinter,
+		aipListRequestsLinter,
+		aipResourceNameFieldsLinter,
+		aipUpdateMaskFieldMaskLinter,
+		aipUpdateRequestsLinter,
+		commentsNoCStyleLinter,
+		enumFieldNamesUpperSnakeCaseLinter,
+		enumNamesUpperCamelCaseLinter,
+		enumsNoAllowAliasLinter,
+		messageFieldNamesLowerSnakeCaseLinter,
+		messageNamesUpperCamelCaseLinter,
+		oneofNamesLowerSnakeCaseLinter,
+		packageIsDeclaredLinter,
+		packageLowerSnakeCaseLinter,
+		packageMajorVersionedLinter,
+		packagesSameInDirLinter,
+		rpcNamesCamelCaseLinter,
+		rpcNamesCapitalizedLinter,
+		serviceNamesUpperCamelCaseLinter,
+		syntaxProto3Linter,
+		wktDirectlyImportedLinter,
+	}
+
 	// DefaultGroup is the default group.
 	DefaultGroup = "default"
 
 	// AllGroup is the group of all known linters.
 	AllGroup = "all"
 
+	// AIPGroup is the group of the AIPLinters.
+	AIPGroup = "aip"
+
 	// GroupToLinters is the map from linter group to the corresponding slice of linters.
 	GroupToLinters = map[string][]Linter{
 		DefaultGroup: DefaultLinters,
 		AllGroup:     AllLinters,
+		AIPGroup:     AIPLinters,
 	}
 )
 
@@ -138,7 +213,13 @@ func init() {
 
 // Runner runs a lint job.
 type Runner interface {
+	// Run returns the lint failures for the ProtoSet, including the
+	// failures of the lint plugins, and excluding the failures in the
+	// baseline file if one is configured.
 	Run(*file.ProtoSet) ([]*text.Failure, error)
+	// GenerateBaseline writes the current lint failures for the
+	// ProtoSet to the configured baseline file.
+	GenerateBaseline(*file.ProtoSet) error
 }
 
 // RunnerOption is an option for a new Runner.
@@ -153,6 +234,28 @@ func RunnerWithLogger(logger *zap.Logger) RunnerOption {
 	}
 }
 
+// RunnerWithFileDescriptorSet returns a RunnerOption that uses the given
+// FileDescriptorSet for the DescriptorLinters and the lint plugins.
+//
+// The FileDescriptorSet should include imports and source code info.
+// This is required if any DescriptorLinters or lint plugins are used.
+func RunnerWithFileDescriptorSet(fileDescriptorSet *descriptor.FileDescriptorSet) RunnerOption {
+	return func(runner *runner) {
+		runner.fileDescriptorSet = fileDescriptorSet
+	}
+}
+
+// RunnerWithPreviousFileDescriptorSet returns a RunnerOption that uses the
+// given FileDescriptorSet as the previous state of the files, for the
+// DescriptorLinters that check deleted types such as DELETED_FIELDS_RESERVED.
+//
+// If this is not set, these DescriptorLinters do not return any failures.
+func RunnerWithPreviousFileDescriptorSet(previousFileDescriptorSet *descriptor.FileDescriptorSet) RunnerOption {
+	return func(runner *runner) {
+		runner.previousFileDescriptorSet = previousFileDescriptorSet
+	}
+}
+
 // NewRunner returns a new Runner.
 func NewRunner(options ...RunnerOption) Runner {
 	return newRunner(options...)
@@ -172,6 +275,10 @@ type Linter interface {
 	// slice and does not return an error. An error is returned if something
 	// unexpected happens. Callers should verify the files are compilable
 	// before running this.
+	//
+	// Failures can have suggested Edits that fix them, such as renaming
+	// an element. The Runner adds the edits that update the references
+	// to renamed messages and enums.
 	Check(dirPath string, descriptors []*proto.Proto) ([]*text.Failure, error)
 }
 
@@ -185,36 +292,197 @@ func NewLinter(id string, purpose string, addCheck func(func(*text.Failure), str
 	return newBaseLinter(id, purpose, addCheck)
 }
 
+// DescriptorLinter is a Linter that checks the compiled FileDescriptorProtos
+// of the files instead of the parsed files, so that it can resolve types
+// across files.
+//
+// Check returns no failures for a DescriptorLinter, CheckMultiple calls
+// CheckFileDescriptors instead.
+type DescriptorLinter interface {
+	Linter
+	// Check the compiled files in a common directory.
+	// If there is a lint failure, this returns it in the
+	// slice and does not return an error. An error is returned if something
+	// unexpected happens.
+	CheckFileDescriptors(dirPath string, fileDescriptors []*FileDescriptor) ([]*text.Failure, error)
+}
+
+// NewDescriptorLinter is a convenience function that returns a new
+// DescriptorLinter for the given parameters, using a function to record failures.
+//
+// The ID will be upper-cased.
+//
+// Failures returned from check do not need to set the ID, this will be overwritten.
+func NewDescriptorLinter(id string, purpose string, addCheck func(func(*text.Failure), string, []*FileDescriptor) error) DescriptorLinter {
+	return newBaseDescriptorLinter(id, purpose, addCheck)
+}
+
+// Parameter is a parameter of a ParameterizedLinter.
+type Parameter struct {
+	// Name is the name of the parameter, such as suffix.
+	Name string
+	// Description is a human-readable description of the parameter.
+	Description string
+	// Default is the value that is used if the parameter is not configured.
+	Default string
+	// Values are the valid values of the parameter.
+	// If empty, any value is valid.
+	Values []string
+	// List is true if the value is a comma-separated list of values.
+	List bool
+	// Validate returns an error if the value is invalid, if set.
+	// It is called with the whole value, even if List is true.
+	Validate func(string) error
+}
+
+// ParameterizedLinter is a Linter that takes parameters from the
+// lint.rules.parameters section of the configuration file.
+type ParameterizedLinter interface {
+	Linter
+	// Parameters returns the parameters of this Linter.
+	Parameters() []*Parameter
+	// WithParameters returns a copy of this Linter that uses the given
+	// parameter values. Parameters that are not given use their defaults.
+	// An error is returned if a parameter is unknown or a value is invalid.
+	WithParameters(map[string]string) (ParameterizedLinter, error)
+}
+
+// NewParameterizedLinter is a convenience function that returns a new
+// ParameterizedLinter for the given parameters, using a function to record failures.
+//
+// The check function is called with the value of every parameter.
+//
+// The ID will be upper-cased.
+//
+// Failures returned from check do not need to set the ID, this will be overwritten.
+func NewParameterizedLinter(id string, purpose string, parameters []*Parameter, addCheck func(func(*text.Failure), string, []*proto.Proto, map[string]string) error) ParameterizedLinter {
+	return newBaseParameterizedLinter(id, purpose, parameters, addCheck)
+}
+
+// ParameterizedDescriptorLinter is a DescriptorLinter that takes parameters
+// from the lint.rules.parameters section of the configuration file.
+//
+// The ParameterizedLinters returned by WithParameters are also DescriptorLinters.
+type ParameterizedDescriptorLinter interface {
+	DescriptorLinter
+	// Parameters returns the parameters of this Linter.
+	Parameters() []*Parameter
+	// WithParameters returns a copy of this Linter that uses the given
+	// parameter values. Parameters that are not given use their defaults.
+	// An error is returned if a parameter is unknown or a value is invalid.
+	WithParameters(map[string]string) (ParameterizedLinter, error)
+}
+
+// NewParameterizedDescriptorLinter is a convenience function that returns a new
+// ParameterizedDescriptorLinter for the given parameters, using a function to record failures.
+//
+// The check function is called with the value of every parameter.
+//
+// The ID will be upper-cased.
+//
+// Failures returned from check do not need to set the ID, this will be overwritten.
+func NewParameterizedDescriptorLinter(id string, purpose string, parameters []*Parameter, addCheck func(func(*text.Failure), string, []*FileDescriptor, map[string]string) error) ParameterizedDescriptorLinter {
+	return newBaseParameterizedDescriptorLinter(id, purpose, parameters, addCheck)
+}
+
 // GetLinters returns the Linters for the LintConfig.
 //
 // The configuration is expected to be valid, deduplicated, and all upper-case.
 // IncludeIDs and ExcludeIDs MUST NOT have an intersection.
 //
 // If the config came from the settings package, this is already validated.
+//
+// The configured parameters are applied to the ParameterizedLinters.
+//
+// If a group is configured, the configured linters are applied to the
+// group instead of the default group.
 func GetLinters(config settings.LintConfig) ([]Linter, error) {
+	if err := checkGroups(config.Groups); err != nil {
+		return nil, err
+	}
 	var linters []Linter
-	if !config.NoDefault {
+	if config.Group != "" {
+		groupLinters, err := GetGroupLinters(config, config.Group)
+		if err != nil {
+			return nil, err
+		}
+		linters = groupLinters
+	} else if !config.NoDefault {
 		linters = DefaultLinters
 	}
-	if len(config.IncludeIDs) == 0 && len(config.ExcludeIDs) == 0 {
+	return withParameters(applyIDs(linters, config.IncludeIDs, config.ExcludeIDs), config.IDToParameters)
+}
+
+// GetGroupLinters returns the Linters for the group, which is either
+// one of GroupToLinters or one of the Groups of the LintConfig.
+//
+// The group is expected to be all lowercase.
+func GetGroupLinters(config settings.LintConfig, group string) ([]Linter, error) {
+	if err := checkGroups(config.Groups); err != nil {
+		return nil, err
+	}
+	if linters, ok := GroupToLinters[group]; ok {
 		return linters, nil
 	}
+	lintGroup, ok := config.Groups[group]
+	if !ok {
+		return nil, fmt.Errorf("unknown lint group: %s", group)
+	}
+	return applyIDs(GroupToLinters[lintGroup.Group], lintGroup.IncludeIDs, lintGroup.ExcludeIDs), nil
+}
+
+// checkGroups returns an error if one of the Groups of a LintConfig has
+// the name of one of GroupToLinters, or does not start from one of them.
+func checkGroups(groups map[string]settings.LintGroup) error {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, ok := GroupToLinters[name]; ok {
+			return fmt.Errorf("lint group %s can not have the name of a built-in group", name)
+		}
+		if baseGroup := groups[name].Group; baseGroup != "" {
+			if _, ok := GroupToLinters[baseGroup]; !ok {
+				return fmt.Errorf("lint group %s must start from one of the built-in groups %s, but was %q", name, strings.Join(getBuiltinGroups(), ", "), baseGroup)
+			}
+		}
+	}
+	return nil
+}
+
+// getBuiltinGroups returns the sorted names of GroupToLinters.
+func getBuiltinGroups() []string {
+	builtinGroups := make([]string, 0, len(GroupToLinters))
+	for group := range GroupToLinters {
+		builtinGroups = append(builtinGroups, group)
+	}
+	sort.Strings(builtinGroups)
+	return builtinGroups
+}
+
+// applyIDs returns the linters with the linters for includeIDs added
+// and the linters for excludeIDs removed.
+func applyIDs(linters []Linter, includeIDs []string, excludeIDs []string) []Linter {
+	if len(includeIDs) == 0 && len(excludeIDs) == 0 {
+		return linters
+	}
 
-	// Apply the configured linters to the default group.
-	linterMap := make(map[string]Linter, len(linters)+len(config.IncludeIDs)-len(config.ExcludeIDs))
+	linterMap := make(map[string]Linter, len(linters)+len(includeIDs))
 	for _, l := range linters {
 		linterMap[l.ID()] = l
 	}
-	if len(config.IncludeIDs) > 0 {
+	if len(includeIDs) > 0 {
 		for _, l := range AllLinters {
-			for _, id := range config.IncludeIDs {
+			for _, id := range includeIDs {
 				if l.ID() == id {
 					linterMap[id] = l
 				}
 			}
 		}
 	}
-	for _, excludeID := range config.ExcludeIDs {
+	for _, excludeID := range excludeIDs {
 		delete(linterMap, excludeID)
 	}
 
@@ -222,7 +490,7 @@ func GetLinters(config settings.LintConfig) ([]Linter, error) {
 	for _, l := range linterMap {
 		result = append(result, l)
 	}
-	return result, nil
+	return result
 }
 
 // GetDirPathToDescriptors is a convenience function that gets the
@@ -250,63 +518,157 @@ func GetDirPathToDescriptors(protoSet *file.ProtoSet) (map[string][]*proto.Proto
 	return dirPathToDescriptors, nil
 }
 
+// GetDirPathToFileDescriptors is a convenience function that gets the
+// FileDescriptors for the given ProtoSet from the compiled FileDescriptorSet.
+//
+// The FileDescriptorSet is expected to include imports and source code info.
+// An error is returned if any file of the ProtoSet is not in the
+// FileDescriptorSet, as DescriptorLinters would silently skip it otherwise.
+func GetDirPathToFileDescriptors(protoSet *file.ProtoSet, fileDescriptorSet *descriptor.FileDescriptorSet) (map[string][]*FileDescriptor, error) {
+	nameToDisplayPath := getNameToDisplayPath(fileDescriptorSet, protoSet)
+	displayPathToFileDescriptorProto := make(map[string]*descriptor.FileDescriptorProto, len(nameToDisplayPath))
+	for _, fileDescriptorProto := range fileDescriptorSet.File {
+		if displayPath, ok := nameToDisplayPath[fileDescriptorProto.GetName()]; ok {
+			displayPathToFileDescriptorProto[displayPath] = fileDescriptorProto
+		}
+	}
+	var unmatchedDisplayPaths []string
+	dirPathToFileDescriptors := make(map[string][]*FileDescriptor, len(protoSet.DirPathToFiles))
+	for dirPath, protoFiles := range protoSet.DirPathToFiles {
+		var fileDescriptors []*FileDescriptor
+		for _, protoFile := range protoFiles {
+			fileDescriptorProto, ok := displayPathToFileDescriptorProto[protoFile.DisplayPath]
+			if !ok {
+				unmatchedDisplayPaths = append(unmatchedDisplayPaths, protoFile.DisplayPath)
+				continue
+			}
+			fileDescriptors = append(fileDescriptors, newFileDescriptor(fileDescriptorProto, protoFile.DisplayPath, fileDescriptorSet))
+		}
+		dirPathToFileDescriptors[dirPath] = fileDescriptors
+	}
+	if len(unmatchedDisplayPaths) > 0 {
+		sort.Strings(unmatchedDisplayPaths)
+		return nil, fmt.Errorf("could not find the compiled FileDescriptorProtos for files %s, the files must be within an include path", strings.Join(unmatchedDisplayPaths, ", "))
+	}
+	return dirPathToFileDescriptors, nil
+}
+
 // CheckMultiple is a convenience function that checks multiple linters and multiple descriptors.
-func CheckMultiple(linters []Linter, dirPathToDescriptors map[string][]*proto.Proto, ignoreIDToFilePaths map[string][]string) ([]*text.Failure, error) {
+//
+// DescriptorLinters are checked with the FileDescriptors of the same
+// directory, and dirPathToFileDescriptors is required if any
+// DescriptorLinters are given.
+//
+// The failures of lint plugins are merged into the result, if any are given.
+// Their filenames are expected to be the filenames of the descriptors.
+//
+// Failures that are suppressed by "// prototool:lint-ignore" comments are dropped,
+// as are failures for the files and packages that are ignored for their ID.
+func CheckMultiple(linters []Linter, dirPathToDescriptors map[string][]*proto.Proto, dirPathToFileDescriptors map[string][]*FileDescriptor, ignoreIDToFilePaths map[string][]string, ignoreIDToPackages map[string][]string, pluginFailures ...*text.Failure) ([]*text.Failure, error) {
+	ignores := &ignores{
+		idToFilePaths: ignoreIDToFilePaths,
+		idToPackages:  ignoreIDToPackages,
+	}
 	var allFailures []*text.Failure
 	for dirPath, descriptors := range dirPathToDescriptors {
+		filenameToSuppressions := getFilenameToSuppressions(descriptors)
 		for _, linter := range linters {
-			failures, err := checkOne(linter, dirPath, descriptors, ignoreIDToFilePaths)
+			var failures []*text.Failure
+			var err error
+			if descriptorLinter, ok := linter.(DescriptorLinter); ok {
+				if dirPathToFileDescriptors == nil {
+					return nil, fmt.Errorf("no FileDescriptors given for linter %s", linter.ID())
+				}
+				failures, err = checkOneFileDescriptors(descriptorLinter, dirPath, dirPathToFileDescriptors[dirPath], ignores, filenameToSuppressions)
+			} else {
+				failures, err = checkOne(linter, dirPath, descriptors, ignores, filenameToSuppressions)
+			}
 			if err != nil {
 				return nil, err
 			}
 			allFailures = append(allFailures, failures...)
 		}
+		failures, err := filterPluginFailures(pluginFailures, descriptors, ignores, filenameToSuppressions)
+		if err != nil {
+			return nil, err
+		}
+		allFailures = append(allFailures, failures...)
+		// this must be done after all other linters are checked
+		if linterIn(lintIgnoreCommentsUsedLinter, linters) {
+			failures, err := getUnusedSuppressionFailures(descriptors, filenameToSuppressions, ignores)
+			if err != nil {
+				return nil, err
+			}
+			allFailures = append(allFailures, filterSuppressed(lintIgnoreCommentsUsedLinter.ID(), failures, filenameToSuppressions)...)
+		}
 	}
 	text.SortFailures(allFailures)
 	return allFailures, nil
 }
 
-func checkOne(linter Linter, dirPath string, descriptors []*proto.Proto, ignoreIDToFilePaths map[string][]string) ([]*text.Failure, error) {
-	filteredDescriptors, err := filterIgnores(linter, descriptors, ignoreIDToFilePaths)
+func checkOne(linter Linter, dirPath string, descriptors []*proto.Proto, ignores *ignores, filenameToSuppressions map[string][]*suppression) ([]*text.Failure, error) {
+	filteredDescriptors, err := filterIgnores(linter, descriptors, ignores)
+	if err != nil {
+		return nil, err
+	}
+	failures, err := linter.Check(dirPath, filteredDescriptors)
 	if err != nil {
 		return nil, err
 	}
-	return linter.Check(dirPath, filteredDescriptors)
+	return filterSuppressed(linter.ID(), failures, filenameToSuppressions), nil
 }
 
-func filterIgnores(linter Linter, descriptors []*proto.Proto, ignoreIDToFilePaths map[string][]string) ([]*proto.Proto, error) {
-	var filteredDescriptors []*proto.Proto
-	for _, descriptor := range descriptors {
-		ignore, err := shouldIgnore(linter, descriptor, ignoreIDToFilePaths)
+func checkOneFileDescriptors(linter DescriptorLinter, dirPath string, fileDescriptors []*FileDescriptor, ignores *ignores, filenameToSuppressions map[string][]*suppression) ([]*text.Failure, error) {
+	var filteredFileDescriptors []*FileDescriptor
+	for _, fileDescriptor := range fileDescriptors {
+		ignore, err := ignores.shouldIgnore(linter.ID(), fileDescriptor.Filename, fileDescriptor.GetPackage())
 		if err != nil {
 			return nil, err
 		}
 		if !ignore {
-			filteredDescriptors = append(filteredDescriptors, descriptor)
+			filteredFileDescriptors = append(filteredFileDescriptors, fileDescriptor)
 		}
 	}
-	return filteredDescriptors, nil
+	failures, err := linter.CheckFileDescriptors(dirPath, filteredFileDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	return filterSuppressed(linter.ID(), failures, filenameToSuppressions), nil
 }
 
-func shouldIgnore(linter Linter, descriptor *proto.Proto, ignoreIDToFilePaths map[string][]string) (bool, error) {
-	filePath := descriptor.Filename
-	var err error
-	if !filepath.IsAbs(filePath) {
-		filePath, err = filepath.Abs(filePath)
-		if err != nil {
-			return false, err
+// filterPluginFailures returns the plugin failures for the descriptors
+// that are not ignored or suppressed for the IDs of the failures.
+func filterPluginFailures(pluginFailures []*text.Failure, descriptors []*proto.Proto, ignores *ignores, filenameToSuppressions map[string][]*suppression) ([]*text.Failure, error) {
+	var filteredFailures []*text.Failure
+	for _, descriptor := range descriptors {
+		for _, failure := range pluginFailures {
+			if failure.Filename != descriptor.Filename {
+				continue
+			}
+			ignore, err := ignores.shouldIgnore(failure.LintID, descriptor.Filename, getPackageName(descriptor))
+			if err != nil {
+				return nil, err
+			}
+			if !ignore {
+				filteredFailures = append(filteredFailures, filterSuppressed(failure.LintID, []*text.Failure{failure}, filenameToSuppressions)...)
+			}
 		}
 	}
-	ignoreFilePaths, ok := ignoreIDToFilePaths[linter.ID()]
-	if !ok {
-		return false, nil
-	}
-	for _, ignoreFilePath := range ignoreFilePaths {
-		if filePath == ignoreFilePath {
-			return true, nil
+	return filteredFailures, nil
+}
+
+func filterIgnores(linter Linter, descriptors []*proto.Proto, ignores *ignores) ([]*proto.Proto, error) {
+	var filteredDescriptors []*proto.Proto
+	for _, descriptor := range descriptors {
+		ignore, err := ignores.shouldIgnore(linter.ID(), descriptor.Filename, getPackageName(descriptor))
+		if err != nil {
+			return nil, err
+		}
+		if !ignore {
+			filteredDescriptors = append(filteredDescriptors, descriptor)
 		}
 	}
-	return false, nil
+	return filteredDescriptors, nil
 }
 
 func copyLintersWithout(linters []Linter, remove ...Linter) []Linter {
@@ -0,0 +1,124 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// withParameters returns the linters with the parameters applied to the
+// ParameterizedLinters, keyed by linter ID.
+//
+// Parameters for linters that are not in linters are not applied, but
+// it is an error to set parameters for a linter that does not exist or
+// does not take parameters.
+func withParameters(linters []Linter, idToParameters map[string]map[string]string) ([]Linter, error) {
+	if len(idToParameters) == 0 {
+		return linters, nil
+	}
+	for id := range idToParameters {
+		linter := getLinter(AllLinters, id)
+		if linter == nil {
+			return nil, fmt.Errorf("parameters set for unknown linter %s", id)
+		}
+		if _, ok := linter.(ParameterizedLinter); !ok {
+			return nil, fmt.Errorf("parameters set for linter %s which does not take parameters", id)
+		}
+	}
+	result := make([]Linter, len(linters))
+	for i, linter := range linters {
+		result[i] = linter
+		parameterizedLinter, ok := linter.(ParameterizedLinter)
+		if !ok {
+			continue
+		}
+		values, ok := idToParameters[linter.ID()]
+		if !ok {
+			continue
+		}
+		withValues, err := parameterizedLinter.WithParameters(values)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = withValues
+	}
+	return result, nil
+}
+
+func getLinter(linters []Linter, id string) Linter {
+	for _, linter := range linters {
+		if linter.ID() == id {
+			return linter
+		}
+	}
+	return nil
+}
+
+func getParameter(parameters []*Parameter, name string) *Parameter {
+	for _, parameter := range parameters {
+		if parameter.Name == name {
+			return parameter
+		}
+	}
+	return nil
+}
+
+func validateParameterValue(parameter *Parameter, value string) error {
+	if parameter.Validate != nil {
+		if err := parameter.Validate(value); err != nil {
+			return err
+		}
+	}
+	if len(parameter.Values) == 0 {
+		return nil
+	}
+	values := []string{value}
+	if parameter.List {
+		values = splitParameterList(value)
+	}
+	for _, value := range values {
+		if !stringIn(value, parameter.Values) {
+			return fmt.Errorf("%q is not one of %s", value, strings.Join(parameter.Values, ", "))
+		}
+	}
+	return nil
+}
+
+// splitParameterList splits the value of a list parameter.
+func splitParameterList(value string) []string {
+	var values []string
+	for _, value := range strings.Split(value, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func stringIn(s string, values []string) bool {
+	for _, value := range values {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/file"
+	"github.com/uber/prototool/internal/settings"
+	"github.com/uber/prototool/internal/text"
+)
+
+// pluginRequest is the JSON request written to the stdin of a lint plugin.
+type pluginRequest struct {
+	// FileDescriptorSet is the serialized FileDescriptorSet of the files
+	// to lint and their imports, including source code info.
+	// This is base64-encoded in JSON.
+	FileDescriptorSet []byte `json:"file_descriptor_set"`
+	// Files are the names of the files to lint in the FileDescriptorSet.
+	Files      []string          `json:"files"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// pluginResponse is the JSON response read from the stdout of a lint plugin.
+type pluginResponse struct {
+	// Failures are the lint failures. The filenames of the failures are
+	// the names of the files in the FileDescriptorSet, and the lint IDs
+	// are without the ID prefix of the plugin.
+	Failures []*text.Failure `json:"failures"`
+}
+
+// runPlugins runs the lint plugins and returns their failures, with the
+// filenames set to the display paths of the files and the lint IDs
+// prefixed with the ID prefixes of the plugins.
+func runPlugins(plugins []settings.LintPlugin, fileDescriptorSet *descriptor.FileDescriptorSet, protoSet *file.ProtoSet) ([]*text.Failure, error) {
+	nameToDisplayPath := getNameToDisplayPath(fileDescriptorSet, protoSet)
+	files := make([]string, 0, len(nameToDisplayPath))
+	for name := range nameToDisplayPath {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	fileDescriptorSetData, err := proto.Marshal(fileDescriptorSet)
+	if err != nil {
+		return nil, err
+	}
+	var allFailures []*text.Failure
+	for _, plugin := range plugins {
+		failures, err := runPlugin(plugin, &pluginRequest{
+			FileDescriptorSet: fileDescriptorSetData,
+			Files:             files,
+			Parameters:        plugin.Parameters,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, failure := range failures {
+			displayPath, ok := nameToDisplayPath[failure.Filename]
+			if !ok {
+				return nil, fmt.Errorf("lint plugin %s returned a failure for %q which is not a file to lint", plugin.Path, failure.Filename)
+			}
+			failure.Filename = displayPath
+			failure.LintID = getPluginLintID(plugin, failure.LintID)
+			// edits are only suggested by the built-in linters
+			failure.Edits = nil
+		}
+		allFailures = append(allFailures, failures...)
+	}
+	return allFailures, nil
+}
+
+func runPlugin(plugin settings.LintPlugin, request *pluginRequest) ([]*text.Failure, error) {
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+	cmd := exec.Command(plugin.Path)
+	cmd.Stdin = bytes.NewReader(requestData)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if errString := strings.TrimSpace(stderr.String()); errString != "" {
+			return nil, fmt.Errorf("lint plugin %s failed: %v: %s", plugin.Path, err, errString)
+		}
+		return nil, fmt.Errorf("lint plugin %s failed: %v", plugin.Path, err)
+	}
+	response := &pluginResponse{}
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("lint plugin %s returned an invalid response: %v", plugin.Path, err)
+	}
+	return response.Failures, nil
+}
+
+// getPluginLintID returns the lint ID of a failure of the plugin.
+//
+// If the plugin did not set an ID, this is the ID prefix of the plugin.
+func getPluginLintID(plugin settings.LintPlugin, id string) string {
+	if id == "" {
+		return plugin.IDPrefix
+	}
+	return plugin.IDPrefix + "_" + strings.ToUpper(id)
+}
+
+// getNameToDisplayPath returns a map from the names of the files in the
+// FileDescriptorSet to the display paths of the files in the ProtoSet.
+//
+// The names are relative to the include path that protoc found the file
+// in, so each file is matched on its path relative to the first include
+// path that contains it, with the include paths in the order that
+// protoc.getIncludes gives them. Imports that are not in the ProtoSet
+// are not in the map.
+func getNameToDisplayPath(fileDescriptorSet *descriptor.FileDescriptorSet, protoSet *file.ProtoSet) map[string]string {
+	names := make(map[string]struct{}, len(fileDescriptorSet.File))
+	for _, fileDescriptorProto := range fileDescriptorSet.File {
+		names[fileDescriptorProto.GetName()] = struct{}{}
+	}
+	includePaths := getIncludePaths(protoSet)
+	nameToDisplayPath := make(map[string]string)
+	for _, protoFiles := range protoSet.DirPathToFiles {
+		for _, protoFile := range protoFiles {
+			name, ok := getIncludeRelativeName(includePaths, protoFile.Path)
+			if !ok {
+				continue
+			}
+			if _, ok := names[name]; ok {
+				nameToDisplayPath[name] = protoFile.DisplayPath
+			}
+		}
+	}
+	return nameToDisplayPath
+}
+
+// getIncludePaths returns the include paths that the files of the ProtoSet
+// are compiled with, which are the configured include paths followed by
+// the directory of the config file, or the working directory if there is
+// no config file.
+func getIncludePaths(protoSet *file.ProtoSet) []string {
+	configDirPath := protoSet.Config.DirPath
+	if configDirPath == "" {
+		configDirPath = protoSet.WorkDirPath
+	}
+	return append(append([]string{}, protoSet.Config.Compile.IncludePaths...), configDirPath)
+}
+
+// getIncludeRelativeName returns the path of the file relative to the
+// first include path that contains it, with forward slashes as in the
+// names of compiled files.
+func getIncludeRelativeName(includePaths []string, filePath string) (string, bool) {
+	for _, includePath := range includePaths {
+		rel, err := filepath.Rel(includePath, filePath)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		return filepath.ToSlash(rel), true
+	}
+	return "", false
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/uber/prototool/internal/location"
+)
+
+// descriptorType is a message or enum in a FileDescriptorSet.
+type descriptorType struct {
+	// file is the file the type is defined in.
+	file *descriptor.FileDescriptorProto
+	// path is the location path of the type in its file.
+	path location.Path
+	// isEnum is true if the type is an enum, and false if it is a message.
+	isEnum bool
+	// isMapEntry is true if the type is the generated entry message of a map field.
+	isMapEntry bool
+	// references are the type names of the messages and enums that are
+	// the types of the fields of a message.
+	references []string
+}
+
+// getTypeNameToType returns the messages and enums in the FileDescriptorSet,
+// including nested types, keyed by their fully-qualified type names with a
+// leading dot, such as .foo.Bar.
+func getTypeNameToType(fileDescriptorSet *descriptor.FileDescriptorSet) map[string]*descriptorType {
+	typeNameToType := make(map[string]*descriptorType)
+	for typeName, message := range getTypeNameToMessage(fileDescriptorSet) {
+		var references []string
+		for _, field := range message.GetField() {
+			if field.GetTypeName() != "" {
+				references = append(references, field.GetTypeName())
+			}
+		}
+		typeNameToType[typeName] = &descriptorType{
+			file:       message.file,
+			path:       message.path,
+			isMapEntry: message.GetOptions().GetMapEntry(),
+			references: references,
+		}
+		for i, enum := range message.GetEnumType() {
+			typeNameToType[typeName+"."+enum.GetName()] = &descriptorType{
+				file:   message.file,
+				path:   message.path.Scope(location.MessageEnum, i),
+				isEnum: true,
+			}
+		}
+	}
+	for _, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+		prefix := "."
+		if pkg := fileDescriptorProto.GetPackage(); pkg != "" {
+			prefix = "." + pkg + "."
+		}
+		for i, enum := range fileDescriptorProto.GetEnumType() {
+			typeNameToType[prefix+enum.GetName()] = &descriptorType{
+				file:   fileDescriptorProto,
+				path:   location.Path{}.Scope(location.Enum, i),
+				isEnum: true,
+			}
+		}
+	}
+	return typeNameToType
+}
+
+// getUsedTypeNames returns the type names of the types that are reachable
+// from the root type names by following the types of message fields.
+//
+// The messages that the used types are nested in are also used.
+func getUsedTypeNames(typeNameToType map[string]*descriptorType, rootTypeNames []string) map[string]struct{} {
+	usedTypeNames := make(map[string]struct{})
+	queue := rootTypeNames
+	for len(queue) > 0 {
+		typeName := queue[0]
+		queue = queue[1:]
+		if _, ok := usedTypeNames[typeName]; ok {
+			continue
+		}
+		usedType, ok := typeNameToType[typeName]
+		if !ok {
+			continue
+		}
+		usedTypeNames[typeName] = struct{}{}
+		queue = append(queue, usedType.references...)
+	}
+	for typeName := range usedTypeNames {
+		for i := strings.LastIndex(typeName, "."); i > 0; i = strings.LastIndex(typeName, ".") {
+			typeName = typeName[:i]
+			if _, ok := typeNameToType[typeName]; !ok {
+				break
+			}
+			usedTypeNames[typeName] = struct{}{}
+		}
+	}
+	return usedTypeNames
+}
+
+// getRootTypeNames returns the type names of the request and response
+// types of all RPCs, and of the types and extendees of all extensions,
+// in the FileDescriptorSet.
+func getRootTypeNames(fileDescriptorSet *descriptor.FileDescriptorSet) []string {
+	var rootTypeNames []string
+	for _, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+		for _, service := range fileDescriptorProto.GetService() {
+			for _, method := range service.GetMethod() {
+				rootTypeNames = append(rootTypeNames, method.GetInputType(), method.GetOutputType())
+			}
+		}
+		for _, extension := range getExtensions(fileDescriptorProto) {
+			rootTypeNames = append(rootTypeNames, extension.GetExtendee())
+			if extension.GetTypeName() != "" {
+				rootTypeNames = append(rootTypeNames, extension.GetTypeName())
+			}
+		}
+	}
+	return rootTypeNames
+}
+
+// getExtensions returns the extensions defined in the file, including
+// the extensions nested in messages.
+func getExtensions(fileDescriptorProto *descriptor.FileDescriptorProto) []*descriptor.FieldDescriptorProto {
+	extensions := append([]*descriptor.FieldDescriptorProto(nil), fileDescriptorProto.GetExtension()...)
+	var addNested func([]*descriptor.DescriptorProto)
+	addNested = func(descriptorProtos []*descriptor.DescriptorProto) {
+		for _, descriptorProto := range descriptorProtos {
+			extensions = append(extensions, descriptorProto.GetExtension()...)
+			addNested(descriptorProto.GetNestedType())
+		}
+	}
+	addNested(fileDescriptorProto.GetMessageType())
+	return extensions
+}
+
+// getSortedTypeNamesInFile returns the sorted type names of the types
+// that are defined in the file.
+func getSortedTypeNamesInFile(typeNameToType map[string]*descriptorType, fileDescriptorProto *descriptor.FileDescriptorProto) []string {
+	var typeNames []string
+	for typeName, definedType := range typeNameToType {
+		if definedType.file == fileDescriptorProto {
+			typeNames = append(typeNames, typeName)
+		}
+	}
+	sort.Strings(typeNames)
+	return typeNames
+}
+
+// getOptionNumbers returns the field numbers that are set in the options
+// message, which include the numbers of the custom options that are set.
+//
+// The options message may be a nil pointer, in which case no numbers are returned.
+func getOptionNumbers(options proto.Message) ([]int32, error) {
+	if reflect.ValueOf(options).IsNil() {
+		return nil, nil
+	}
+	data, err := proto.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	var numbers []int32
+	for len(data) > 0 {
+		key, n := proto.DecodeVarint(data)
+		if n == 0 {
+			return nil, fmt.Errorf("could not decode options %s", proto.MessageName(options))
+		}
+		data = data[n:]
+		switch key & 7 {
+		case proto.WireVarint:
+			_, n = proto.DecodeVarint(data)
+		case proto.WireFixed64:
+			n = 8
+		case proto.WireBytes:
+			length, m := proto.DecodeVarint(data)
+			n = m + int(length)
+			if m == 0 {
+				n = 0
+			}
+		case proto.WireFixed32:
+			n = 4
+		default:
+			n = 0
+		}
+		if n == 0 || n > len(data) {
+			return nil, fmt.Errorf("could not decode options %s", proto.MessageName(options))
+		}
+		data = data[n:]
+		numbers = append(numbers, int32(key>>3))
+	}
+	return numbers, nil
+}
+
+// getPackageToImportedPackages returns the packages that the files of
+// each package in the FileDescriptorSet import, sorted by name.
+//
+// Files without a package and imports of the same package are skipped.
+func getPackageToImportedPackages(fileDescriptorSet *descriptor.FileDescriptorSet) map[string][]string {
+	nameToFile := getNameToFile(fileDescriptorSet)
+	packageToImportedPackageSet := make(map[string]map[string]struct{})
+	for _, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+		pkg := fileDescriptorProto.GetPackage()
+		if pkg == "" {
+			continue
+		}
+		for _, dependency := range fileDescriptorProto.GetDependency() {
+			importedPackage := nameToFile[dependency].GetPackage()
+			if importedPackage == "" || importedPackage == pkg {
+				continue
+			}
+			if _, ok := packageToImportedPackageSet[pkg]; !ok {
+				packageToImportedPackageSet[pkg] = make(map[string]struct{})
+			}
+			packageToImportedPackageSet[pkg][importedPackage] = struct{}{}
+		}
+	}
+	packageToImportedPackages := make(map[string][]string, len(packageToImportedPackageSet))
+	for pkg, importedPackageSet := range packageToImportedPackageSet {
+		importedPackages := make([]string, 0, len(importedPackageSet))
+		for importedPackage := range importedPackageSet {
+			importedPackages = append(importedPackages, importedPackage)
+		}
+		sort.Strings(importedPackages)
+		packageToImportedPackages[pkg] = importedPackages
+	}
+	return packageToImportedPackages
+}
+
+// getNameToFile returns the files in the FileDescriptorSet keyed by name.
+func getNameToFile(fileDescriptorSet *descriptor.FileDescriptorSet) map[string]*descriptor.FileDescriptorProto {
+	nameToFile := make(map[string]*descriptor.FileDescriptorProto, len(fileDescriptorSet.GetFile()))
+	for _, fileDescriptorProto := range fileDescriptorSet.GetFile() {
+		nameToFile[fileDescriptorProto.GetName()] = fileDescriptorProto
+	}
+	return nameToFile
+}
@@ -21,13 +21,19 @@
 package lint
 
 import (
+	"fmt"
+
+	"github.com/emicklei/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/uber/prototool/internal/file"
 	"github.com/uber/prototool/internal/text"
 	"go.uber.org/zap"
 )
 
 type runner struct {
-	logger *zap.Logger
+	logger                    *zap.Logger
+	fileDescriptorSet         *descriptor.FileDescriptorSet
+	previousFileDescriptorSet *descriptor.FileDescriptorSet
 }
 
 func newRunner(options ...RunnerOption) *runner {
@@ -41,13 +47,85 @@ func newRunner(options ...RunnerOption) *runner {
 }
 
 func (r *runner) Run(protoSet *file.ProtoSet) ([]*text.Failure, error) {
-	linters, err := GetLinters(protoSet.Config.Lint)
+	dirPathToDescriptors, failures, err := r.check(protoSet)
 	if err != nil {
 		return nil, err
 	}
-	dirPathToDescriptors, err := GetDirPathToDescriptors(protoSet)
+	if protoSet.Config.Lint.BaselineFilePath == "" {
+		return failures, nil
+	}
+	baselineFailures, err := readBaseline(protoSet.Config.Lint.BaselineFilePath)
 	if err != nil {
 		return nil, err
 	}
-	return CheckMultiple(linters, dirPathToDescriptors, protoSet.Config.Lint.IgnoreIDToFilePaths)
+	return filterBaseline(protoSet, dirPathToDescriptors, failures, baselineFailures)
+}
+
+func (r *runner) GenerateBaseline(protoSet *file.ProtoSet) error {
+	if protoSet.Config.Lint.BaselineFilePath == "" {
+		return fmt.Errorf("no lint baseline file set, set baseline in the lint section of your configuration file")
+	}
+	dirPathToDescriptors, failures, err := r.check(protoSet)
+	if err != nil {
+		return err
+	}
+	baselineFailures, err := getBaselineFailures(protoSet, dirPathToDescriptors, failures)
+	if err != nil {
+		return err
+	}
+	r.logger.Debug("writing lint baseline", zap.String("path", protoSet.Config.Lint.BaselineFilePath), zap.Int("failures", len(baselineFailures)))
+	return writeBaseline(protoSet.Config.Lint.BaselineFilePath, baselineFailures)
+}
+
+func (r *runner) check(protoSet *file.ProtoSet) (map[string][]*proto.Proto, []*text.Failure, error) {
+	linters, err := GetLinters(protoSet.Config.Lint)
+	if err != nil {
+		return nil, nil, err
+	}
+	dirPathToDescriptors, err := GetDirPathToDescriptors(protoSet)
+	if err != nil {
+		return nil, nil, err
+	}
+	var pluginFailures []*text.Failure
+	if len(protoSet.Config.Lint.Plugins) > 0 {
+		if r.fileDescriptorSet == nil {
+			return nil, nil, fmt.Errorf("lint plugins are configured but no FileDescriptorSet was given")
+		}
+		r.logger.Debug("running lint plugins", zap.Int("plugins", len(protoSet.Config.Lint.Plugins)))
+		pluginFailures, err = runPlugins(protoSet.Config.Lint.Plugins, r.fileDescriptorSet, protoSet)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	var dirPathToFileDescriptors map[string][]*FileDescriptor
+	if r.fileDescriptorSet != nil {
+		dirPathToFileDescriptors, err = GetDirPathToFileDescriptors(protoSet, r.fileDescriptorSet)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, fileDescriptors := range dirPathToFileDescriptors {
+			for _, fileDescriptor := range fileDescriptors {
+				fileDescriptor.PreviousFileDescriptorSet = r.previousFileDescriptorSet
+			}
+		}
+	}
+	failures, err := CheckMultiple(linters, dirPathToDescriptors, dirPathToFileDescriptors, protoSet.Config.Lint.IgnoreIDToFilePaths, protoSet.Config.Lint.IgnoreIDToPackages, pluginFailures...)
+	if err != nil {
+		return nil, nil, err
+	}
+	addReferenceEdits(dirPathToDescriptors, failures)
+	setSeverities(failures, protoSet.Config.Lint.IDToSeverity)
+	return dirPathToDescriptors, failures, nil
+}
+
+// setSeverities sets the severity of the failures to the configured
+// severity of their linter, or to error if none is configured.
+func setSeverities(failures []*text.Failure, idToSeverity map[string]string) {
+	for _, failure := range failures {
+		if severity, ok := idToSeverity[failure.LintID]; ok {
+			failure.Severity = severity
+		} else {
+			failure.Severity = text.SeverityError
+		}
+	}
 }
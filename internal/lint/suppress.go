@@ -0,0 +1,246 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lint
+
+import (
+	"strings"
+	"text/scanner"
+
+	"github.com/emicklei/proto"
+	"github.com/uber/prototool/internal/text"
+)
+
+const (
+	// lintIgnorePrefix is the prefix of a comment line that suppresses
+	// failures for the next element, including all of its children.
+	//
+	//  // prototool:lint-ignore ENUM_FIELD_PREFIXES ENUM_ZERO_VALUES_INVALID
+	//  enum Foo {
+	lintIgnorePrefix = "prototool:lint-ignore"
+	// lintIgnoreFilePrefix is the prefix of a comment line that suppresses
+	// failures for the entire file. It can be anywhere in the file.
+	//
+	//  // prototool:lint-ignore-file ENUM_FIELD_PREFIXES
+	lintIgnoreFilePrefix = "prototool:lint-ignore-file"
+)
+
+var lintIgnoreCommentsUsedLinter = NewLinter(
+	"LINT_IGNORE_COMMENTS_USED",
+	`Verifies that all "// prototool:lint-ignore" and "// prototool:lint-ignore-file" comments suppress at least one failure.`,
+	// this is handled by CheckMultiple, as this needs the
+	// results of all other linters
+	func(func(*text.Failure), string, []*proto.Proto) error { return nil },
+)
+
+// suppression is a lint-ignore comment for a single lint ID.
+type suppression struct {
+	id string
+	// position is the position of the comment.
+	position scanner.Position
+	// fileLevel is true if the entire file is suppressed.
+	fileLevel bool
+	// startLine and endLine are the lines of the suppressed element,
+	// including its children, if this is not a file-level suppression.
+	startLine int
+	endLine   int
+	used      bool
+}
+
+// suppresses returns true if the failure is suppressed.
+func (s *suppression) suppresses(id string, failure *text.Failure) bool {
+	if s.id != id {
+		return false
+	}
+	return s.fileLevel || (s.startLine <= failure.Line && failure.Line <= s.endLine)
+}
+
+// getFilenameToSuppressions returns the suppressions of the
+// descriptors keyed by their filename.
+func getFilenameToSuppressions(descriptors []*proto.Proto) map[string][]*suppression {
+	filenameToSuppressions := make(map[string][]*suppression, len(descriptors))
+	for _, descriptor := range descriptors {
+		var suppressions []*suppression
+		addElementSuppressions(&suppressions, descriptor.Elements)
+		filenameToSuppressions[descriptor.Filename] = suppressions
+	}
+	return filenameToSuppressions
+}
+
+// addElementSuppressions adds the suppressions of the given elements
+// and their children.
+//
+// An element is suppressed by either its own comment, or by a comment
+// that is separated from the element by a blank line, which is parsed
+// as a separate element.
+func addElementSuppressions(suppressions *[]*suppression, elements []proto.Visitee) {
+	var detachedComment *proto.Comment
+	for _, element := range elements {
+		if comment, ok := element.(*proto.Comment); ok {
+			// a detached comment may be followed by another one,
+			// in which case only file-level suppressions apply
+			addCommentSuppressions(suppressions, detachedComment, scanner.Position{}, 0)
+			detachedComment = comment
+			continue
+		}
+		position, comment, children := getElementInfo(element)
+		endLine := getElementEndLine(element)
+		addCommentSuppressions(suppressions, detachedComment, position, endLine)
+		addCommentSuppressions(suppressions, comment, position, endLine)
+		detachedComment = nil
+		addElementSuppressions(suppressions, children)
+	}
+	addCommentSuppressions(suppressions, detachedComment, scanner.Position{}, 0)
+}
+
+// addCommentSuppressions adds the suppressions of the comment for the
+// element at the given position. If there is no element, the position
+// is empty and only file-level suppressions are added.
+func addCommentSuppressions(suppressions *[]*suppression, comment *proto.Comment, position scanner.Position, endLine int) {
+	if comment == nil {
+		return
+	}
+	for _, line := range comment.Lines {
+		line = strings.TrimSpace(line)
+		fileLevel := false
+		switch {
+		case strings.HasPrefix(line, lintIgnoreFilePrefix):
+			line = strings.TrimPrefix(line, lintIgnoreFilePrefix)
+			fileLevel = true
+		case strings.HasPrefix(line, lintIgnorePrefix):
+			if position.Line == 0 {
+				continue
+			}
+			line = strings.TrimPrefix(line, lintIgnorePrefix)
+		default:
+			continue
+		}
+		// the prefix must be followed by whitespace
+		if line == "" || !strings.ContainsAny(line[:1], " \t") {
+			continue
+		}
+		for _, id := range strings.FieldsFunc(line, isLintIgnoreSeparator) {
+			*suppressions = append(*suppressions, &suppression{
+				id:        strings.ToUpper(id),
+				position:  comment.Position,
+				fileLevel: fileLevel,
+				startLine: position.Line,
+				endLine:   endLine,
+			})
+		}
+	}
+}
+
+func isLintIgnoreSeparator(r rune) bool {
+	return r == ' ' || r == '\t' || r == ','
+}
+
+// getElementInfo returns the position, comment, and children of the element.
+func getElementInfo(element proto.Visitee) (scanner.Position, *proto.Comment, []proto.Visitee) {
+	switch e := element.(type) {
+	case *proto.Syntax:
+		return e.Position, e.Comment, nil
+	case *proto.Package:
+		return e.Position, e.Comment, nil
+	case *proto.Import:
+		return e.Position, e.Comment, nil
+	case *proto.Option:
+		return e.Position, e.Comment, nil
+	case *proto.Message:
+		return e.Position, e.Comment, e.Elements
+	case *proto.Enum:
+		return e.Position, e.Comment, e.Elements
+	case *proto.EnumField:
+		return e.Position, e.Comment, nil
+	case *proto.NormalField:
+		return e.Position, e.Comment, nil
+	case *proto.MapField:
+		return e.Position, e.Comment, nil
+	case *proto.Oneof:
+		return e.Position, e.Comment, e.Elements
+	case *proto.OneOfField:
+		return e.Position, e.Comment, nil
+	case *proto.Group:
+		return e.Position, e.Comment, e.Elements
+	case *proto.Service:
+		return e.Position, e.Comment, e.Elements
+	case *proto.RPC:
+		return e.Position, e.Comment, nil
+	case *proto.Reserved:
+		return e.Position, e.Comment, nil
+	case *proto.Extensions:
+		return e.Position, e.Comment, nil
+	default:
+		return scanner.Position{}, nil, nil
+	}
+}
+
+// getElementEndLine returns the last line of the element that a failure
+// can be reported on, which is the line of the element or its last child.
+func getElementEndLine(element proto.Visitee) int {
+	position, _, children := getElementInfo(element)
+	endLine := position.Line
+	for _, child := range children {
+		if childEndLine := getElementEndLine(child); childEndLine > endLine {
+			endLine = childEndLine
+		}
+	}
+	return endLine
+}
+
+// filterSuppressed returns the failures for the linter that are not
+// suppressed, and marks the suppressions that were used.
+func filterSuppressed(id string, failures []*text.Failure, filenameToSuppressions map[string][]*suppression) []*text.Failure {
+	var filteredFailures []*text.Failure
+	for _, failure := range failures {
+		suppressed := false
+		for _, suppression := range filenameToSuppressions[failure.Filename] {
+			if suppression.suppresses(id, failure) {
+				suppression.used = true
+				suppressed = true
+			}
+		}
+		if !suppressed {
+			filteredFailures = append(filteredFailures, failure)
+		}
+	}
+	return filteredFailures
+}
+
+// getUnusedSuppressionFailures returns a failure for each suppression that
+// did not suppress any failure, for the descriptors that are not ignored
+// for lintIgnoreCommentsUsedLinter.
+func getUnusedSuppressionFailures(descriptors []*proto.Proto, filenameToSuppressions map[string][]*suppression, ignores *ignores) ([]*text.Failure, error) {
+	filteredDescriptors, err := filterIgnores(lintIgnoreCommentsUsedLinter, descriptors, ignores)
+	if err != nil {
+		return nil, err
+	}
+	var failures []*text.Failure
+	for _, descriptor := range filteredDescriptors {
+		for _, suppression := range filenameToSuppressions[descriptor.Filename] {
+			if suppression.used || suppression.id == lintIgnoreCommentsUsedLinter.ID() {
+				continue
+			}
+			failure := text.NewFailuref(suppression.position, lintIgnoreCommentsUsedLinter.ID(), "Lint ignore comment for %s does not suppress any failures.", suppression.id)
+			failures = append(failures, failure)
+		}
+	}
+	return failures, nil
+}
@@ -42,6 +42,7 @@ type ID int32
 const (
 	Syntax         ID = 12
 	Package        ID = 2
+	Dependency     ID = 3
 	FileOption     ID = 8
 	Message        ID = 4
 	Field          ID = 2
@@ -51,21 +52,55 @@ const (
 	Enum           ID = 5
 	EnumValue      ID = 2
 	EnumOption     ID = 3
+	MessageOption  ID = 7
+	FieldOption    ID = 8
 	Service        ID = 6
 	Method         ID = 2
 	MethodRequest  ID = 2
 	MethodResponse ID = 3
 
-	Name            ID = 1
-	EnumValueNumber ID = 2
-	FieldLabel      ID = 4
-	FieldNumber     ID = 3
-	FieldType       ID = 5
-	FieldTypeName   ID = 6
+	FileExtension        ID = 7
+	MessageExtension     ID = 6
+	ExtensionRange       ID = 5
+	MessageReservedRange ID = 9
+	MessageReservedName  ID = 10
+	EnumReservedRange    ID = 4
+	EnumReservedName     ID = 5
+
+	Name              ID = 1
+	EnumValueNumber   ID = 2
+	FieldExtendee     ID = 2
+	FieldLabel        ID = 4
+	FieldNumber       ID = 3
+	FieldType         ID = 5
+	FieldTypeName     ID = 6
+	FieldDefaultValue ID = 7
 
 	AllowAlias         ID = 2
 	JavaPackage        ID = 1
 	JavaOuterClassname ID = 8
 	JavaMultipleFiles  ID = 10
 	GoPackage          ID = 11
+
+	OptimizeFor         ID = 9
+	CcGenericServices   ID = 16
+	JavaGenericServices ID = 17
+	PyGenericServices   ID = 18
+	FileDeprecated      ID = 23
+	JavaStringCheckUtf8 ID = 27
+	CcEnableArenas      ID = 31
+	ObjcClassPrefix     ID = 36
+	CsharpNamespace     ID = 37
+	SwiftPrefix         ID = 39
+	PhpClassPrefix      ID = 40
+	PhpNamespace        ID = 41
+
+	MessageSetWireFormat         ID = 1
+	NoStandardDescriptorAccessor ID = 2
+	Deprecated                   ID = 3
+	FieldCtype                   ID = 1
+	FieldPacked                  ID = 2
+	FieldLazy                    ID = 5
+	FieldJstype                  ID = 6
+	FieldWeak                    ID = 10
 )
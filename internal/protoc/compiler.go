@@ -73,6 +73,7 @@ type compiler struct {
 	protocURL           string
 	doGen               bool
 	doFileDescriptorSet bool
+	doSourceCodeInfo    bool
 }
 
 func newCompiler(options ...CompilerOption) *compiler {
@@ -326,7 +327,9 @@ func (c *compiler) getCmdMetas(protoSet *file.ProtoSet) (cmdMetas []*cmdMeta, re
 			// so we do --include_imports to get all necessary info in the output file descriptor set
 			if descriptorSetTempFilePath != "" {
 				// TODO(pedge): we will need source info if we switch out emicklei/proto
-				//iArgs = append(iArgs, "--include_source_info")
+				if c.doSourceCodeInfo {
+					iArgs = append(iArgs, "--include_source_info")
+				}
 				iArgs = append(iArgs, "--include_imports")
 			}
 			for _, protoFile := range protoFiles {
@@ -200,6 +200,16 @@ func CompilerWithFileDescriptorSet() CompilerOption {
 	}
 }
 
+// CompilerWithSourceCodeInfo says to include source code info in the
+// returned FileDescriptorSets.
+//
+// This has no effect unless CompilerWithFileDescriptorSet is also used.
+func CompilerWithSourceCodeInfo() CompilerOption {
+	return func(compiler *compiler) {
+		compiler.doSourceCodeInfo = true
+	}
+}
+
 // NewCompiler returns a new Compiler.
 func NewCompiler(options ...CompilerOption) Compiler {
 	return newCompiler(options...)
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -205,9 +206,13 @@ func externalConfigToConfig(e ExternalConfig, dirPath string) (Config, error) {
 		includePaths = append(includePaths, includePath)
 	}
 	ignoreIDToFilePaths := make(map[string][]string)
+	var ignoreIDToPackages map[string][]string
 	for _, ignore := range e.Lint.Ignores {
 		id := strings.ToUpper(ignore.ID)
 		for _, protoFilePath := range ignore.Files {
+			if _, err := path.Match(filepath.ToSlash(protoFilePath), ""); err != nil {
+				return Config{}, fmt.Errorf("invalid lint ignore file %q for %s: %v", protoFilePath, id, err)
+			}
 			if !filepath.IsAbs(protoFilePath) {
 				protoFilePath = filepath.Join(dirPath, protoFilePath)
 			}
@@ -217,6 +222,94 @@ func externalConfigToConfig(e ExternalConfig, dirPath string) (Config, error) {
 			}
 			ignoreIDToFilePaths[id] = append(ignoreIDToFilePaths[id], protoFilePath)
 		}
+		for _, pkg := range ignore.Packages {
+			if _, err := path.Match(pkg, ""); err != nil {
+				return Config{}, fmt.Errorf("invalid lint ignore package %q for %s: %v", pkg, id, err)
+			}
+			if ignoreIDToPackages == nil {
+				ignoreIDToPackages = make(map[string][]string)
+			}
+			ignoreIDToPackages[id] = append(ignoreIDToPackages[id], pkg)
+		}
+	}
+	var lintIDToParameters map[string]map[string]string
+	for id, parameters := range e.Lint.Rules.Parameters {
+		if lintIDToParameters == nil {
+			lintIDToParameters = make(map[string]map[string]string)
+		}
+		lintIDToParameters[strings.ToUpper(id)] = parameters
+	}
+	var lintIDToSeverity map[string]string
+	for id, severity := range e.Lint.Rules.Severities {
+		lintSeverity := strings.ToLower(severity)
+		switch lintSeverity {
+		case "error", "warning", "info":
+		default:
+			return Config{}, fmt.Errorf("unknown lint severity %q for %s, must be one of error, warning, info", severity, id)
+		}
+		if lintIDToSeverity == nil {
+			lintIDToSeverity = make(map[string]string)
+		}
+		lintIDToSeverity[strings.ToUpper(id)] = lintSeverity
+	}
+	lintGroups, err := getLintGroups(e, dirPath)
+	if err != nil {
+		return Config{}, err
+	}
+	lintGroup := strings.ToLower(e.Lint.Rules.Group)
+	if lintGroup != "" && e.Lint.Rules.NoDefault {
+		return Config{}, fmt.Errorf("lint rules can not have both no_default and group set")
+	}
+	lintBaselineFilePath := e.Lint.Baseline
+	if lintBaselineFilePath != "" {
+		if !filepath.IsAbs(lintBaselineFilePath) {
+			lintBaselineFilePath = filepath.Join(dirPath, lintBaselineFilePath)
+		}
+		lintBaselineFilePath = filepath.Clean(lintBaselineFilePath)
+	}
+	lintPlugins := make([]LintPlugin, 0, len(e.Lint.Plugins))
+	lintPluginIDPrefixes := make(map[string]struct{}, len(e.Lint.Plugins))
+	for _, plugin := range e.Lint.Plugins {
+		if plugin.Path == "" {
+			return Config{}, fmt.Errorf("path required for lint plugin")
+		}
+		idPrefix := strings.ToUpper(plugin.IDPrefix)
+		if idPrefix == "" {
+			return Config{}, fmt.Errorf("id_prefix required for lint plugin %s", plugin.Path)
+		}
+		if _, ok := lintPluginIDPrefixes[idPrefix]; ok {
+			return Config{}, fmt.Errorf("duplicate id_prefix %s for lint plugins", idPrefix)
+		}
+		lintPluginIDPrefixes[idPrefix] = struct{}{}
+		pluginPath := plugin.Path
+		// paths such as ./bin/plugin are relative to the config file,
+		// while names such as plugin are looked up on the PATH
+		if !filepath.IsAbs(pluginPath) && strings.ContainsRune(pluginPath, filepath.Separator) {
+			pluginPath = filepath.Clean(filepath.Join(dirPath, pluginPath))
+		}
+		lintPlugins = append(lintPlugins, LintPlugin{
+			Path:       pluginPath,
+			IDPrefix:   idPrefix,
+			Parameters: plugin.Parameters,
+		})
+	}
+	// to make testing easier
+	if len(lintPlugins) == 0 {
+		lintPlugins = nil
+	}
+
+	breakFailSeverity := strings.ToLower(e.Break.FailSeverity)
+	switch breakFailSeverity {
+	case "", "wire", "source", "warn":
+	default:
+		return Config{}, fmt.Errorf("unknown break fail_severity %q, must be one of wire, source, warn", e.Break.FailSeverity)
+	}
+	breakIgnoreFilePaths := make([]string, 0, len(e.Break.Ignores.Files))
+	for _, protoFilePath := range strs.DedupeSort(e.Break.Ignores.Files, nil) {
+		if !filepath.IsAbs(protoFilePath) {
+			protoFilePath = filepath.Join(dirPath, protoFilePath)
+		}
+		breakIgnoreFilePaths = append(breakIgnoreFilePaths, filepath.Clean(protoFilePath))
 	}
 
 	genPlugins := make([]GenPlugin, len(e.Gen.Plugins))
@@ -288,7 +381,14 @@ func externalConfigToConfig(e ExternalConfig, dirPath string) (Config, error) {
 			IncludeIDs:          strs.DedupeSort(e.Lint.Rules.Add, strings.ToUpper),
 			ExcludeIDs:          strs.DedupeSort(e.Lint.Rules.Remove, strings.ToUpper),
 			NoDefault:           e.Lint.Rules.NoDefault,
+			Group:               lintGroup,
+			Groups:              lintGroups,
 			IgnoreIDToFilePaths: ignoreIDToFilePaths,
+			IgnoreIDToPackages:  ignoreIDToPackages,
+			IDToParameters:      lintIDToParameters,
+			IDToSeverity:        lintIDToSeverity,
+			BaselineFilePath:    lintBaselineFilePath,
+			Plugins:             lintPlugins,
 		},
 		Gen: GenConfig{
 			GoPluginOptions: GenGoPluginOptions{
@@ -297,6 +397,13 @@ func externalConfigToConfig(e ExternalConfig, dirPath string) (Config, error) {
 			},
 			Plugins: genPlugins,
 		},
+		Break: BreakConfig{
+			FailSeverity:           breakFailSeverity,
+			IgnorePackages:         strs.DedupeSort(e.Break.Ignores.Packages, nil),
+			IgnoreFilePaths:        breakIgnoreFilePaths,
+			IgnoreUnstablePackages: e.Break.IgnoreUnstablePackages,
+			PackageScope:           e.Break.PackageScope,
+		},
 	}
 
 	for _, genPlugin := range config.Gen.Plugins {
@@ -323,6 +430,49 @@ func externalConfigToConfig(e ExternalConfig, dirPath string) (Config, error) {
 	return config, nil
 }
 
+// getLintGroups returns the lint groups of the ExternalConfig, including
+// the lint groups of the configuration files referenced by group_files.
+//
+// The group_files of the referenced configuration files are not followed.
+func getLintGroups(e ExternalConfig, dirPath string) (map[string]LintGroup, error) {
+	var lintGroups map[string]LintGroup
+	externalConfigs := []ExternalConfig{e}
+	for _, groupFilePath := range e.Lint.GroupFiles {
+		if !filepath.IsAbs(groupFilePath) {
+			groupFilePath = filepath.Join(dirPath, groupFilePath)
+		}
+		groupExternalConfig, err := getExternalConfig(filepath.Clean(groupFilePath))
+		if err != nil {
+			return nil, fmt.Errorf("could not read lint group file: %v", err)
+		}
+		externalConfigs = append(externalConfigs, groupExternalConfig)
+	}
+	for _, externalConfig := range externalConfigs {
+		for _, group := range externalConfig.Lint.Groups {
+			name := strings.ToLower(group.Name)
+			if name == "" {
+				return nil, fmt.Errorf("name required for lint group")
+			}
+			if _, ok := lintGroups[name]; ok {
+				return nil, fmt.Errorf("duplicate lint group %s", name)
+			}
+			lintGroup := LintGroup{
+				Group:      strings.ToLower(group.Group),
+				IncludeIDs: strs.DedupeSort(group.Add, strings.ToUpper),
+				ExcludeIDs: strs.DedupeSort(group.Remove, strings.ToUpper),
+			}
+			if intersection := strs.Intersection(lintGroup.IncludeIDs, lintGroup.ExcludeIDs); len(intersection) > 0 {
+				return nil, fmt.Errorf("lint group %s had intersection of %v between add and remove", name, intersection)
+			}
+			if lintGroups == nil {
+				lintGroups = make(map[string]LintGroup)
+			}
+			lintGroups[name] = lintGroup
+		}
+	}
+	return lintGroups, nil
+}
+
 func getExcludePrefixesForDir(dirPath string) ([]string, error) {
 	filePath, err := getSingleFilePathForDir(dirPath)
 	if err != nil {
@@ -142,6 +142,8 @@ type Config struct {
 	Lint LintConfig
 	// The gen config.
 	Gen GenConfig
+	// The break config.
+	Break BreakConfig
 }
 
 // CompileConfig is the compile config.
@@ -171,6 +173,14 @@ type CreateConfig struct {
 type LintConfig struct {
 	// NoDefault is set to exclude the default set of linters.
 	NoDefault bool
+	// Group is the group of linters to use instead of the default set of
+	// linters, either a built-in group or one of Groups.
+	// Expected to be all lowercase.
+	// Expected to be empty if NoDefault is set.
+	Group string
+	// Groups are the custom groups of linters, keyed by name.
+	// Names expected to be all lowercase.
+	Groups map[string]LintGroup
 	// IncludeIDs are the list of linter IDs to use in addition to the defaults.
 	// Expected to be all uppercase.
 	// Expected to be unique.
@@ -182,9 +192,88 @@ type LintConfig struct {
 	// Expected to have no overlap with IncludeIDs.
 	ExcludeIDs []string
 	// IgnoreIDToFilePaths is the map of ID to absolute file path to ignore.
+	// A file path can also be a directory, to ignore all files in the
+	// directory, or a glob pattern, where ** matches any number of directories.
 	// IDs expected to be all upper-case.
 	// File paths expected to be absolute paths.
 	IgnoreIDToFilePaths map[string][]string
+	// IgnoreIDToPackages is the map of ID to Protobuf package to ignore.
+	// A package can also be a glob pattern that is matched per package
+	// component, such as foo.v1alpha.*, where ** matches any number of components.
+	// IDs expected to be all upper-case.
+	IgnoreIDToPackages map[string][]string
+	// IDToParameters is the map of linter ID to the parameters of the linter.
+	// IDs expected to be all upper-case.
+	IDToParameters map[string]map[string]string
+	// IDToSeverity is the map of linter ID to the severity of the failures
+	// of the linter. Linters that are not in the map have the severity error.
+	// IDs expected to be all upper-case.
+	// Severities expected to be one of error, warning, info.
+	IDToSeverity map[string]string
+	// BaselineFilePath is the path to the baseline file of lint failures
+	// to ignore. If empty, no baseline is used.
+	// Expected to be an absolute path.
+	BaselineFilePath string
+	// Plugins are the external lint plugins to run.
+	Plugins []LintPlugin
+}
+
+// LintGroup is a custom group of linters.
+type LintGroup struct {
+	// Group is the built-in group of linters to start from, which is
+	// validated by the lint package. If empty, the group starts with
+	// no linters.
+	// Expected to be all lowercase.
+	Group string
+	// IncludeIDs are the list of linter IDs to add to the group.
+	// Expected to be all uppercase.
+	// Expected to be unique.
+	// Expected to have no overlap with ExcludeIDs.
+	IncludeIDs []string
+	// ExcludeIDs are the list of linter IDs to remove from the group.
+	// Expected to be all uppercase.
+	// Expected to be unique.
+	// Expected to have no overlap with IncludeIDs.
+	ExcludeIDs []string
+}
+
+// LintPlugin is an external lint plugin.
+//
+// The plugin is sent the compiled FileDescriptorSet on stdin, and
+// returns its lint failures on stdout, both as JSON.
+type LintPlugin struct {
+	// The path to the executable. If this is not an absolute path and
+	// contains no path separator, the executable is looked up on the PATH.
+	Path string
+	// IDPrefix is prepended to the IDs of the failures of the plugin,
+	// separated by an underscore.
+	// Expected to be all uppercase.
+	// Expected to be unique.
+	IDPrefix string
+	// Parameters are passed to the plugin with each request.
+	Parameters map[string]string
+}
+
+// BreakConfig is the break config.
+type BreakConfig struct {
+	// FailSeverity is the minimum severity of breaking changes that result
+	// in a failure. If empty, wire and source breaking changes result in a failure.
+	// Expected to be one of wire, source, warn, or empty.
+	FailSeverity string
+	// IgnorePackages are the packages to ignore breaking changes for.
+	// Expected to be unique.
+	IgnorePackages []string
+	// IgnoreFilePaths are the files to ignore breaking changes for.
+	// Expected to be absolute paths.
+	// Expected to be unique.
+	IgnoreFilePaths []string
+	// IgnoreUnstablePackages says to ignore breaking changes for packages
+	// with an alpha or beta version suffix, such as foo.v1alpha1.
+	IgnoreUnstablePackages bool
+	// PackageScope says to compare types by their fully-qualified name within
+	// their package instead of comparing files by name, so that types can be
+	// moved between files of the same package.
+	PackageScope bool
 }
 
 // GenConfig is the gen config.
@@ -257,14 +346,31 @@ type ExternalConfig struct {
 	} `json:"create,omitempty" yaml:"create,omitempty"`
 	Lint struct {
 		Ignores []struct {
-			ID    string   `json:"id,omitempty" yaml:"id,omitempty"`
-			Files []string `json:"files,omitempty" yaml:"files,omitempty"`
+			ID       string   `json:"id,omitempty" yaml:"id,omitempty"`
+			Files    []string `json:"files,omitempty" yaml:"files,omitempty"`
+			Packages []string `json:"packages,omitempty" yaml:"packages,omitempty"`
 		}
 		Rules struct {
-			NoDefault bool     `json:"no_default,omitempty" yaml:"no_default,omitempty"`
-			Add       []string `json:"add" yaml:"add"`
-			Remove    []string `json:"remove" yaml:"remove"`
+			NoDefault  bool                         `json:"no_default,omitempty" yaml:"no_default,omitempty"`
+			Group      string                       `json:"group,omitempty" yaml:"group,omitempty"`
+			Add        []string                     `json:"add" yaml:"add"`
+			Remove     []string                     `json:"remove" yaml:"remove"`
+			Parameters map[string]map[string]string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+			Severities map[string]string            `json:"severities,omitempty" yaml:"severities,omitempty"`
 		}
+		Groups []struct {
+			Name   string   `json:"name,omitempty" yaml:"name,omitempty"`
+			Group  string   `json:"group,omitempty" yaml:"group,omitempty"`
+			Add    []string `json:"add,omitempty" yaml:"add,omitempty"`
+			Remove []string `json:"remove,omitempty" yaml:"remove,omitempty"`
+		} `json:"groups,omitempty" yaml:"groups,omitempty"`
+		GroupFiles []string `json:"group_files,omitempty" yaml:"group_files,omitempty"`
+		Baseline   string   `json:"baseline,omitempty" yaml:"baseline,omitempty"`
+		Plugins    []struct {
+			Path       string            `json:"path,omitempty" yaml:"path,omitempty"`
+			IDPrefix   string            `json:"id_prefix,omitempty" yaml:"id_prefix,omitempty"`
+			Parameters map[string]string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+		} `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 	} `json:"lint,omitempty" yaml:"lint,omitempty"`
 	Gen struct {
 		GoOptions struct {
@@ -279,6 +385,15 @@ type ExternalConfig struct {
 			Path   string `json:"path,omitempty" yaml:"path,omitempty"`
 		} `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 	} `json:"generate,omitempty" yaml:"generate,omitempty"`
+	Break struct {
+		FailSeverity string `json:"fail_severity,omitempty" yaml:"fail_severity,omitempty"`
+		Ignores      struct {
+			Packages []string `json:"packages,omitempty" yaml:"packages,omitempty"`
+			Files    []string `json:"files,omitempty" yaml:"files,omitempty"`
+		} `json:"ignores,omitempty" yaml:"ignores,omitempty"`
+		IgnoreUnstablePackages bool `json:"ignore_unstable_packages,omitempty" yaml:"ignore_unstable_packages,omitempty"`
+		PackageScope           bool `json:"package_scope,omitempty" yaml:"package_scope,omitempty"`
+	} `json:"break,omitempty" yaml:"break,omitempty"`
 }
 
 // ConfigProvider provides Configs.
@@ -106,6 +106,11 @@ func ToUpperSnakeCase(s string) string {
 	return strings.ToUpper(toSnake(s))
 }
 
+// ToLowerSnakeCase converts s to lower_snake_case.
+func ToLowerSnakeCase(s string) string {
+	return strings.ToLower(toSnake(s))
+}
+
 // ToUpperCamelCase converts s to UpperCamelCase.
 //
 // We use this for files, so any delimiter (_, -, or space) is
@@ -130,6 +135,18 @@ func ToUpperCamelCase(s string) string {
 	return output
 }
 
+// ToLowerCamelCase converts s to lowerCamelCase.
+//
+// This is ToUpperCamelCase with the first letter lowercased.
+func ToLowerCamelCase(s string) string {
+	s = ToUpperCamelCase(s)
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
+
 // DedupeSort returns s with no duplicates and no empty strings, sorted.
 // If modifier is not nil, modifier will be applied to each element in s.
 func DedupeSort(s []string, modifier func(string) string) []string {
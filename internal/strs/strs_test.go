@@ -93,6 +93,14 @@ func TestToUpperSnakeCase(t *testing.T) {
 	assert.Equal(t, "FOO_ABBR_CAMEL", ToUpperSnakeCase("FooABBRCamel"))
 }
 
+func TestToLowerSnakeCase(t *testing.T) {
+	assert.Equal(t, "", ToLowerSnakeCase(""))
+	assert.Equal(t, "camel_case", ToLowerSnakeCase("CamelCase"))
+	assert.Equal(t, "camel_case", ToLowerSnakeCase("camelCase"))
+	assert.Equal(t, "camel_case", ToLowerSnakeCase("camel_case"))
+	assert.Equal(t, "abbr_camel", ToLowerSnakeCase("ABBRCamel"))
+}
+
 func TestToUpperCamelCase(t *testing.T) {
 	assert.Equal(t, "", ToUpperCamelCase(""))
 	assert.Equal(t, "", ToUpperCamelCase("  "))
@@ -116,6 +124,14 @@ func TestToUpperCamelCase(t *testing.T) {
 	assert.Equal(t, "CAMELCase", ToUpperCamelCase("  CAMEL case"))
 }
 
+func TestToLowerCamelCase(t *testing.T) {
+	assert.Equal(t, "", ToLowerCamelCase(""))
+	assert.Equal(t, "camelCase", ToLowerCamelCase("camel_case"))
+	assert.Equal(t, "camelCase", ToLowerCamelCase("Camel_case"))
+	assert.Equal(t, "camelCase", ToLowerCamelCase("CamelCase"))
+	assert.Equal(t, "cAMELCase", ToLowerCamelCase("CAMEL_case"))
+}
+
 func TestDedupeSort(t *testing.T) {
 	assert.Equal(t, []string{"a", "b", "c"}, DedupeSort([]string{"b", "A", "c"}, strings.ToLower))
 	assert.Equal(t, []string{"a", "b", "c"}, DedupeSort([]string{"b", "A", "c", "a"}, strings.ToLower))
@@ -40,6 +40,17 @@ const (
 	FailureFieldID
 	// FailureFieldMessage references the Message field of a Failure.
 	FailureFieldMessage
+	// FailureFieldSeverity references the Severity field of a Failure.
+	FailureFieldSeverity
+)
+
+const (
+	// SeverityError is the Severity of a Failure that is an error.
+	SeverityError = "error"
+	// SeverityWarning is the Severity of a Failure that is a warning.
+	SeverityWarning = "warning"
+	// SeverityInfo is the Severity of a Failure that is informational.
+	SeverityInfo = "info"
 )
 
 var (
@@ -51,12 +62,23 @@ var (
 		FailureFieldMessage,
 	}
 
+	// _defaultFailureFieldsWithSeverity are the FailureFields that are printed
+	// instead of DefaultFailureFields for Failures that are not errors.
+	_defaultFailureFieldsWithSeverity = []FailureField{
+		FailureFieldFilename,
+		FailureFieldLine,
+		FailureFieldColumn,
+		FailureFieldSeverity,
+		FailureFieldMessage,
+	}
+
 	_failureFieldToString = map[FailureField]string{
 		FailureFieldFilename: "filename",
 		FailureFieldLine:     "line",
 		FailureFieldColumn:   "column",
 		FailureFieldID:       "id",
 		FailureFieldMessage:  "message",
+		FailureFieldSeverity: "severity",
 	}
 	_stringToFailureField = map[string]FailureField{
 		"filename": FailureFieldFilename,
@@ -64,6 +86,7 @@ var (
 		"column":   FailureFieldColumn,
 		"id":       FailureFieldID,
 		"message":  FailureFieldMessage,
+		"severity": FailureFieldSeverity,
 	}
 )
 
@@ -113,6 +136,29 @@ type Failure struct {
 	Column   int    `json:"column,omitempty"`
 	LintID   string `json:"lint_id,omitempty"`
 	Message  string `json:"message,omitempty"`
+	// Severity is the severity of the Failure, one of SeverityError,
+	// SeverityWarning, or SeverityInfo, or empty if the Failure has no severity.
+	// It is printed with the DefaultFailureFields if it is not SeverityError.
+	Severity string `json:"severity,omitempty"`
+	// Edits are the suggested edits that fix the Failure, if any.
+	Edits []*Edit `json:"edits,omitempty"`
+}
+
+// Edit is a suggested edit that fixes a Failure.
+//
+// The edit replaces Old with New for the element at the position, where
+// Old is the name of the element, or a type that the element references
+// if Reference is set, such as the type of a field. A type also matches
+// the types that it is a prefix of, so that "foo.Bar" can be edited for
+// the type "foo.Bar.Baz". An edit from "/*" to "//" for a comment at the
+// position makes the comment a C++-style comment.
+type Edit struct {
+	Filename  string `json:"filename,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+	Reference bool   `json:"reference,omitempty"`
 }
 
 // FailureWriter is a writer that Failure.Println can accept.
@@ -124,9 +170,16 @@ type FailureWriter interface {
 }
 
 // Fprintln prints the Failure to the writer with the given ordered fields.
+//
+// If the fields are the DefaultFailureFields, the severity of a Failure
+// with a severity other than SeverityError is printed before the message,
+// so that warnings and errors can be told apart.
 func (f *Failure) Fprintln(writer FailureWriter, fields ...FailureField) error {
-	if len(fields) == 0 {
+	if len(fields) == 0 || isDefaultFailureFields(fields) {
 		fields = DefaultFailureFields
+		if f.Severity != "" && f.Severity != SeverityError {
+			fields = _defaultFailureFieldsWithSeverity
+		}
 	}
 	written := false
 	for i, field := range fields {
@@ -177,6 +230,15 @@ func (f *Failure) Fprintln(writer FailureWriter, fields ...FailureField) error {
 			} else {
 				printColon = false
 			}
+		case FailureFieldSeverity:
+			if f.Severity != "" {
+				if _, err := writer.WriteString(f.Severity); err != nil {
+					return err
+				}
+				written = true
+			} else {
+				printColon = false
+			}
 		default:
 			return fmt.Errorf("unknown FailureField: %v", field)
 		}
@@ -194,6 +256,18 @@ func (f *Failure) Fprintln(writer FailureWriter, fields ...FailureField) error {
 	return nil
 }
 
+func isDefaultFailureFields(fields []FailureField) bool {
+	if len(fields) != len(DefaultFailureFields) {
+		return false
+	}
+	for i, field := range fields {
+		if field != DefaultFailureFields[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // String implements fmt.Stringer.
 func (f *Failure) String() string {
 	filename := f.Filename
@@ -234,6 +308,17 @@ func NewFailuref(position scanner.Position, lintID string, format string, args .
 	}
 }
 
+// NewEdit is a helper that returns a new Edit.
+func NewEdit(position scanner.Position, old string, new string) *Edit {
+	return &Edit{
+		Filename: position.Filename,
+		Line:     position.Line,
+		Column:   position.Column,
+		Old:      old,
+		New:      new,
+	}
+}
+
 // SortFailures sorts the Failures, by filename, line, column, id, message.
 func SortFailures(failures []*Failure) {
 	sort.Stable(sortFailures(failures))
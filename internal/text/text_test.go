@@ -43,6 +43,35 @@ func TestFailureFprintln(t *testing.T) {
 		FailureFieldFilename,
 		FailureFieldID,
 	)
+	failure := newTestFailure("foo", 2, 2, "BAR", "hello")
+	failure.Severity = SeverityWarning
+	testFailureFprintln(t, "foo:BAR:warning:hello", failure,
+		FailureFieldFilename,
+		FailureFieldID,
+		FailureFieldSeverity,
+		FailureFieldMessage,
+	)
+	testFailureFprintln(t, "foo:BAR:hello", newTestFailure("foo", 2, 2, "BAR", "hello"),
+		FailureFieldFilename,
+		FailureFieldID,
+		FailureFieldSeverity,
+		FailureFieldMessage,
+	)
+	failure = newTestFailure("foo", 2, 2, "BAR", "hello")
+	failure.Severity = SeverityWarning
+	testFailureFprintln(t, "foo:2:2:warning:hello", failure)
+	testFailureFprintln(t, "foo:2:2:warning:hello", failure, DefaultFailureFields...)
+	failure.Severity = SeverityInfo
+	testFailureFprintln(t, "foo:2:2:info:hello", failure)
+	failure.Severity = SeverityError
+	testFailureFprintln(t, "foo:2:2:hello", failure)
+	testFailureFprintln(t, "foo:2:2:hello", newTestFailure("foo", 2, 2, "BAR", "hello"))
+	failure.Severity = SeverityWarning
+	testFailureFprintln(t, "foo:BAR:hello", failure,
+		FailureFieldFilename,
+		FailureFieldID,
+		FailureFieldMessage,
+	)
 }
 
 func testFailureFprintln(t *testing.T, expected string, failure *Failure, failureFields ...FailureField) {
@@ -55,6 +84,7 @@ func TestParseColonSeparatedFailureFields(t *testing.T) {
 	testParseColonSeparatedFailureFields(t, "", false, DefaultFailureFields...)
 	testParseColonSeparatedFailureFields(t, "filename", false, FailureFieldFilename)
 	testParseColonSeparatedFailureFields(t, "filename:id", false, FailureFieldFilename, FailureFieldID)
+	testParseColonSeparatedFailureFields(t, "filename:severity", false, FailureFieldFilename, FailureFieldSeverity)
 	testParseColonSeparatedFailureFields(t, ":", true)
 	testParseColonSeparatedFailureFields(t, ":filename:id", true)
 	testParseColonSeparatedFailureFields(t, "filename:id:", true)